@@ -36,6 +36,8 @@
 #include <unordered_map>
 #include <sstream>
 #include <mutex>
+#include <condition_variable>
+#include <deque>
 #include <thread>
 #include <algorithm>
 #include <memory>
@@ -51,6 +53,10 @@
 // Default settings
 #define DEFAULT_PORT 8888
 #define MAX_REQUEST_SIZE 8192
+// An /Events subscriber this many events behind is disconnected
+#define MAX_EVENT_QUEUE 256
+// Wire schema of the JSON responses, keep in sync with WireSchemaVersion in schema.go
+#define MCP_SCHEMA_VERSION 1
 
 // Global variables
 int g_pluginHandle;
@@ -60,6 +66,55 @@ int g_httpPort = DEFAULT_PORT;
 std::mutex g_httpMutex;
 SOCKET g_serverSocket = INVALID_SOCKET;
 
+// An /Events subscriber. The debug event callbacks only queue, its own writer thread does the
+// blocking send, so a subscriber that stops reading never stalls x64dbg's debug loop
+struct EventClient {
+    SOCKET socket = INVALID_SOCKET;
+    std::deque<std::string> queue;
+    std::condition_variable ready;
+    bool closed = false;
+};
+
+// /Events subscribers, the debug event callbacks push Server-Sent Events to them
+std::mutex g_eventMutex;
+std::vector<std::shared_ptr<EventClient>> g_eventClients;
+
+// Switches x64dbg to another thread while it is in scope so register reads and writes use that
+// thread's context, an empty id keeps the current thread. x64dbg expressions are hex, so the
+// decimal id of the query is passed as 0x...
+struct ThreadSwitch {
+    DWORD previous = 0;
+    bool ok = true;
+
+    explicit ThreadSwitch(const std::string &id) {
+        if (id.empty()) {
+            return;
+        }
+        DWORD threadId = 0;
+        try {
+            threadId = std::stoul(id, nullptr, 10);
+        } catch (const std::exception &) {
+            ok = false;
+            return;
+        }
+        previous = DbgGetThreadId();
+        std::stringstream cmd;
+        cmd << "switchthread 0x" << std::hex << threadId;
+        ok = DbgCmdExecDirect(cmd.str().c_str());
+        if (!ok) {
+            previous = 0;
+        }
+    }
+
+    ~ThreadSwitch() {
+        if (previous != 0) {
+            std::stringstream cmd;
+            cmd << "switchthread 0x" << std::hex << previous;
+            DbgCmdExecDirect(cmd.str().c_str());
+        }
+    }
+};
+
 // Forward declarations
 bool startHttpServer();
 
@@ -79,6 +134,55 @@ std::unordered_map<std::string, std::string> parseQueryParams(const std::string
 
 std::string urlDecode(const std::string &str);
 
+std::string jsonEscape(const std::string &str);
+
+int resolveModule(std::unordered_map<std::string, std::string> &queryParams, Script::Module::ModuleInfo &info);
+
+std::string moduleInfoJson(const Script::Module::ModuleInfo &info);
+
+std::string moduleSectionJson(const Script::Module::ModuleSectionInfo &section);
+
+std::string moduleExportJson(const Script::Module::ModuleExport &exp);
+
+std::string moduleImportJson(const Script::Module::ModuleImport &imp);
+
+std::string breakpointJson(const BRIDGEBP &bp);
+
+std::string threadJson(const THREADALLINFO &thread, bool current);
+
+std::string memoryPageJson(const MEMPAGE &page);
+
+std::string symbolJson(const SYMBOLINFO &info, const std::string &module, duint base, duint displacement);
+
+struct NearestSymbol;
+
+bool nearestSymbol(duint addr, NearestSymbol &nearest);
+
+int symbolAt(duint addr, std::string &json);
+
+bool registerFromName(const std::string &name, Script::Register::RegisterEnum &reg);
+
+std::string hexBytes(const void *data, size_t size);
+
+bool parseHexBytes(const std::string &hex, std::vector<unsigned char> &bytes);
+
+bool readAvx512(std::vector<std::string> &zmm, std::vector<unsigned long long> &k);
+
+std::string registerContextJson(const REGDUMP &dump);
+
+void publishEvent(const std::string &json);
+
+void addEventClient(SOCKET clientSocket, const std::string &header);
+
+void eventWriter(std::shared_ptr<EventClient> client);
+
+void closeEventClient(EventClient &client);
+
+void closeEventClients();
+
+// Debug event callback
+void cbDebugEvent(CBTYPE cbType, void *callbackInfo);
+
 // Command callback declarations
 bool cbEnableHttpServer(int argc, char *argv[]);
 
@@ -102,6 +206,12 @@ bool pluginInit(PLUG_INITSTRUCT *initStruct) {
     // Register commands
     registerCommands();
 
+    // Register debug event callbacks for /Events
+    for (CBTYPE type: {CB_BREAKPOINT, CB_EXCEPTION, CB_LOADDLL, CB_UNLOADDLL, CB_CREATETHREAD, CB_EXITTHREAD,
+                       CB_EXITPROCESS, CB_PAUSEDEBUG, CB_RESUMEDEBUG, CB_STEPPED}) {
+        _plugin_registercallback(g_pluginHandle, type, cbDebugEvent);
+    }
+
     // Start the HTTP server
     if (startHttpServer()) {
         _plugin_logprintf("x64dbg HTTP Server started on port %d\n", g_httpPort);
@@ -181,6 +291,8 @@ void stopHttpServer() {
             CloseHandle(g_httpServerThread);
             g_httpServerThread = NULL;
         }
+
+        closeEventClients();
     }
 }
 
@@ -206,6 +318,572 @@ std::string urlDecode(const std::string &str) {
     return decoded;
 }
 
+// JSON escape function, module paths and instruction text may contain backslashes and quotes
+std::string jsonEscape(const std::string &str) {
+    std::string escaped;
+    for (unsigned char c : str) {
+        switch (c) {
+            case '"':
+                escaped += "\\\"";
+                break;
+            case '\\':
+                escaped += "\\\\";
+                break;
+            case '\n':
+                escaped += "\\n";
+                break;
+            case '\r':
+                escaped += "\\r";
+                break;
+            case '\t':
+                escaped += "\\t";
+                break;
+            default:
+                if (c < 0x20) {
+                    char buf[8];
+                    sprintf_s(buf, "\\u%04x", c);
+                    escaped += buf;
+                } else {
+                    escaped += (char) c;
+                }
+        }
+    }
+    return escaped;
+}
+
+// Resolve the module of a /Module/* request: addr (any address inside the module), name, or main for the
+// main module. Returns the HTTP status to answer with, 200 when info was filled, 422 when none is given.
+int resolveModule(std::unordered_map<std::string, std::string> &queryParams, Script::Module::ModuleInfo &info) {
+    std::string addrStr = queryParams["addr"];
+    std::string name = queryParams["name"];
+    std::string main = queryParams["main"];
+    bool found = false;
+    if (!addrStr.empty()) {
+        duint addr = 0;
+        try {
+            if (addrStr.substr(0, 2) == "0x") {
+                addr = std::stoull(addrStr.substr(2), nullptr, 16);
+            } else {
+                addr = std::stoull(addrStr, nullptr, 16);
+            }
+        } catch (const std::exception &e) {
+            return 400;
+        }
+        found = Script::Module::InfoFromAddr(addr, &info);
+    } else if (!name.empty()) {
+        found = Script::Module::InfoFromName(name.c_str(), &info);
+    } else if (!main.empty()) {
+        found = Script::Module::GetMainModuleInfo(&info);
+    } else {
+        // the main module is asked for explicitly, a lost address must not turn into it
+        return 422;
+    }
+    return found ? 200 : 404;
+}
+
+// JSON encoders of the module structs, see schema.go for the wire schema
+std::string moduleInfoJson(const Script::Module::ModuleInfo &info) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"name\":\"" << jsonEscape(info.name) << "\",";
+    ss << "\"base\":\"0x" << std::hex << info.base << "\",";
+    ss << "\"size\":\"0x" << std::hex << info.size << "\",";
+    ss << "\"entry\":\"0x" << std::hex << info.entry << "\",";
+    ss << "\"sectionCount\":" << std::dec << info.sectionCount << ",";
+    ss << "\"path\":\"" << jsonEscape(info.path) << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+std::string moduleSectionJson(const Script::Module::ModuleSectionInfo &section) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"name\":\"" << jsonEscape(section.name) << "\",";
+    ss << "\"address\":\"0x" << std::hex << section.addr << "\",";
+    ss << "\"size\":\"0x" << std::hex << section.size << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+std::string moduleExportJson(const Script::Module::ModuleExport &exp) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"ordinal\":\"0x" << std::hex << exp.ordinal << "\",";
+    ss << "\"rva\":\"0x" << std::hex << exp.rva << "\",";
+    ss << "\"va\":\"0x" << std::hex << exp.va << "\",";
+    ss << "\"forwarded\":" << (exp.forwarded ? "true" : "false") << ",";
+    ss << "\"forwardName\":\"" << jsonEscape(exp.forwarded ? exp.forwardName : "") << "\",";
+    ss << "\"name\":\"" << jsonEscape(exp.name) << "\",";
+    ss << "\"undecoratedName\":\"" << jsonEscape(exp.undecoratedName) << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+std::string moduleImportJson(const Script::Module::ModuleImport &imp) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"iatRva\":\"0x" << std::hex << imp.iatRva << "\",";
+    ss << "\"iatVa\":\"0x" << std::hex << imp.iatVa << "\",";
+    ss << "\"ordinal\":\"0x" << std::hex << imp.ordinal << "\",";
+    ss << "\"name\":\"" << jsonEscape(imp.name) << "\",";
+    ss << "\"undecoratedName\":\"" << jsonEscape(imp.undecoratedName) << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+// JSON encoder of BRIDGEBP, see Breakpoint in schema.go
+std::string breakpointJson(const BRIDGEBP &bp) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"type\":" << std::dec << (int) bp.type << ",";
+    ss << "\"addr\":\"0x" << std::hex << bp.addr << "\",";
+    ss << "\"enabled\":" << (bp.enabled ? "true" : "false") << ",";
+    ss << "\"singleshoot\":" << (bp.singleshoot ? "true" : "false") << ",";
+    ss << "\"active\":" << (bp.active ? "true" : "false") << ",";
+    ss << "\"name\":\"" << jsonEscape(bp.name) << "\",";
+    ss << "\"mod\":\"" << jsonEscape(bp.mod) << "\",";
+    ss << "\"slot\":" << std::dec << bp.slot << ",";
+    ss << "\"typeEx\":" << std::dec << (int) bp.typeEx << ",";
+    ss << "\"hwSize\":" << std::dec << (int) bp.hwSize << ",";
+    ss << "\"hitCount\":" << std::dec << bp.hitCount << ",";
+    ss << "\"fastResume\":" << (bp.fastResume ? "true" : "false") << ",";
+    ss << "\"silent\":" << (bp.silent ? "true" : "false") << ",";
+    ss << "\"breakCondition\":\"" << jsonEscape(bp.breakCondition) << "\",";
+    ss << "\"logText\":\"" << jsonEscape(bp.logText) << "\",";
+    ss << "\"logCondition\":\"" << jsonEscape(bp.logCondition) << "\",";
+    ss << "\"commandText\":\"" << jsonEscape(bp.commandText) << "\",";
+    ss << "\"commandCondition\":\"" << jsonEscape(bp.commandCondition) << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+// JSON encoder of THREADALLINFO, see ThreadInfo in schema.go
+std::string threadJson(const THREADALLINFO &thread, bool current) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"number\":" << std::dec << thread.BasicInfo.ThreadNumber << ",";
+    ss << "\"id\":" << std::dec << thread.BasicInfo.ThreadId << ",";
+    ss << "\"handle\":\"0x" << std::hex << (duint) thread.BasicInfo.Handle << "\",";
+    ss << "\"teb\":\"0x" << std::hex << thread.BasicInfo.ThreadLocalBase << "\",";
+    ss << "\"start\":\"0x" << std::hex << thread.BasicInfo.ThreadStartAddress << "\",";
+    ss << "\"cip\":\"0x" << std::hex << thread.ThreadCip << "\",";
+    ss << "\"suspendCount\":" << std::dec << thread.SuspendCount << ",";
+    ss << "\"priority\":" << std::dec << (int) thread.Priority << ",";
+    ss << "\"waitReason\":" << std::dec << (int) thread.WaitReason << ",";
+    ss << "\"lastError\":\"0x" << std::hex << thread.LastError << "\",";
+    ss << "\"name\":\"" << jsonEscape(thread.BasicInfo.threadName) << "\",";
+    ss << "\"current\":" << (current ? "true" : "false");
+    ss << "}";
+    return ss.str();
+}
+
+// JSON encoder of MEMPAGE, see MemoryRegion in schema.go
+std::string memoryPageJson(const MEMPAGE &page) {
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"base\":\"0x" << std::hex << (duint) page.mbi.BaseAddress << "\",";
+    ss << "\"allocationBase\":\"0x" << std::hex << (duint) page.mbi.AllocationBase << "\",";
+    ss << "\"size\":\"0x" << std::hex << (duint) page.mbi.RegionSize << "\",";
+    ss << "\"state\":" << std::dec << page.mbi.State << ",";
+    ss << "\"type\":" << std::dec << page.mbi.Type << ",";
+    ss << "\"allocationProtect\":" << std::dec << page.mbi.AllocationProtect << ",";
+    ss << "\"protect\":" << std::dec << page.mbi.Protect << ",";
+    ss << "\"info\":\"" << jsonEscape(page.info) << "\"";
+    ss << "}";
+    return ss.str();
+}
+
+// JSON encoder of SYMBOLINFO, see SymbolInfo in schema.go
+std::string symbolJson(const SYMBOLINFO &info, const std::string &module, duint base, duint displacement) {
+    const char *type = info.type == sym_import ? "import" : info.type == sym_export ? "export" : "symbol";
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"address\":\"0x" << std::hex << info.addr << "\",";
+    ss << "\"module\":\"" << jsonEscape(module) << "\",";
+    ss << "\"base\":\"0x" << std::hex << base << "\",";
+    ss << "\"decorated\":\"" << jsonEscape(info.decoratedSymbol ? info.decoratedSymbol : "") << "\",";
+    ss << "\"undecorated\":\"" << jsonEscape(info.undecoratedSymbol ? info.undecoratedSymbol : "") << "\",";
+    ss << "\"displacement\":\"0x" << std::hex << displacement << "\",";
+    ss << "\"type\":\"" << type << "\",";
+    ss << "\"ordinal\":" << std::dec << info.ordinal;
+    ss << "}";
+    return ss.str();
+}
+
+struct NearestSymbol {
+    duint addr;
+    bool found;
+    duint best;
+    std::string json;
+    std::string name;
+    std::string module;
+    duint base;
+};
+
+static bool cbNearestSymbol(const SYMBOLPTR *symbol, void *user) {
+    NearestSymbol *nearest = (NearestSymbol *) user;
+    SYMBOLINFOCPP info;
+    DbgGetSymbolInfo(symbol, &info);
+    if (info.addr <= nearest->addr && (!nearest->found || info.addr > nearest->best)) {
+        nearest->found = true;
+        nearest->best = info.addr;
+        nearest->json = symbolJson(info, nearest->module, nearest->base, nearest->addr - info.addr);
+        const char *name = info.undecoratedSymbol && *info.undecoratedSymbol ? info.undecoratedSymbol : info.decoratedSymbol;
+        nearest->name = name ? name : "";
+    }
+    return true;
+}
+
+// Find the symbol at or before addr in its module, module is set when addr is inside one
+bool nearestSymbol(duint addr, NearestSymbol &nearest) {
+    nearest = NearestSymbol();
+    nearest.addr = addr;
+    char module[MAX_MODULE_SIZE] = "";
+    if (!DbgGetModuleAt(addr, module)) {
+        return false;
+    }
+    nearest.module = module;
+    nearest.base = DbgModBaseFromName(module);
+    DbgSymbolEnumRange(nearest.base, addr + 1, SYMBOL_MASK_ALL, cbNearestSymbol, &nearest);
+    return nearest.found;
+}
+
+// Find the symbol at or before addr in its module, returns the HTTP status
+int symbolAt(duint addr, std::string &json) {
+    NearestSymbol nearest;
+    if (!nearestSymbol(addr, nearest)) {
+        json = nearest.module.empty() ? "No module found for this address" : "Symbol not found";
+        return 404;
+    }
+    json = nearest.json;
+    return 200;
+}
+
+// Register names of the script API, case insensitive, the same table as RegisterEnum of the Go client
+bool registerFromName(const std::string &name, Script::Register::RegisterEnum &reg) {
+    static const std::unordered_map<std::string, Script::Register::RegisterEnum> registers = {
+        {"DR0", Script::Register::DR0}, {"DR1", Script::Register::DR1}, {"DR2", Script::Register::DR2},
+        {"DR3", Script::Register::DR3}, {"DR6", Script::Register::DR6}, {"DR7", Script::Register::DR7},
+        {"EAX", Script::Register::EAX}, {"AX", Script::Register::AX}, {"AH", Script::Register::AH},
+        {"AL", Script::Register::AL}, {"EBX", Script::Register::EBX}, {"BX", Script::Register::BX},
+        {"BH", Script::Register::BH}, {"BL", Script::Register::BL}, {"ECX", Script::Register::ECX},
+        {"CX", Script::Register::CX}, {"CH", Script::Register::CH}, {"CL", Script::Register::CL},
+        {"EDX", Script::Register::EDX}, {"DX", Script::Register::DX}, {"DH", Script::Register::DH},
+        {"DL", Script::Register::DL}, {"EDI", Script::Register::EDI}, {"DI", Script::Register::DI},
+        {"ESI", Script::Register::ESI}, {"SI", Script::Register::SI}, {"EBP", Script::Register::EBP},
+        {"BP", Script::Register::BP}, {"ESP", Script::Register::ESP}, {"SP", Script::Register::SP},
+        {"EIP", Script::Register::EIP},
+#ifdef _WIN64
+        {"RAX", Script::Register::RAX}, {"RBX", Script::Register::RBX}, {"RCX", Script::Register::RCX},
+        {"RDX", Script::Register::RDX}, {"RSI", Script::Register::RSI}, {"SIL", Script::Register::SIL},
+        {"RDI", Script::Register::RDI}, {"DIL", Script::Register::DIL}, {"RBP", Script::Register::RBP},
+        {"BPL", Script::Register::BPL}, {"RSP", Script::Register::RSP}, {"SPL", Script::Register::SPL},
+        {"RIP", Script::Register::RIP}, {"R8", Script::Register::R8}, {"R8D", Script::Register::R8D},
+        {"R8W", Script::Register::R8W}, {"R8B", Script::Register::R8B}, {"R9", Script::Register::R9},
+        {"R9D", Script::Register::R9D}, {"R9W", Script::Register::R9W}, {"R9B", Script::Register::R9B},
+        {"R10", Script::Register::R10}, {"R10D", Script::Register::R10D}, {"R10W", Script::Register::R10W},
+        {"R10B", Script::Register::R10B}, {"R11", Script::Register::R11}, {"R11D", Script::Register::R11D},
+        {"R11W", Script::Register::R11W}, {"R11B", Script::Register::R11B}, {"R12", Script::Register::R12},
+        {"R12D", Script::Register::R12D}, {"R12W", Script::Register::R12W}, {"R12B", Script::Register::R12B},
+        {"R13", Script::Register::R13}, {"R13D", Script::Register::R13D}, {"R13W", Script::Register::R13W},
+        {"R13B", Script::Register::R13B}, {"R14", Script::Register::R14}, {"R14D", Script::Register::R14D},
+        {"R14W", Script::Register::R14W}, {"R14B", Script::Register::R14B}, {"R15", Script::Register::R15},
+        {"R15D", Script::Register::R15D}, {"R15W", Script::Register::R15W}, {"R15B", Script::Register::R15B},
+#endif
+        {"CIP", Script::Register::CIP}, {"CSP", Script::Register::CSP}, {"CAX", Script::Register::CAX},
+        {"CBX", Script::Register::CBX}, {"CCX", Script::Register::CCX}, {"CDX", Script::Register::CDX},
+        {"CDI", Script::Register::CDI}, {"CSI", Script::Register::CSI}, {"CBP", Script::Register::CBP},
+        {"CFLAGS", Script::Register::CFLAGS},
+    };
+    std::string upper = name;
+    std::transform(upper.begin(), upper.end(), upper.begin(), ::toupper);
+    auto it = registers.find(upper);
+    if (it == registers.end()) {
+        return false;
+    }
+    reg = it->second;
+    return true;
+}
+
+// Plain hex of a memory image, lowest address first
+std::string hexBytes(const void *data, size_t size) {
+    std::stringstream ss;
+    const unsigned char *bytes = (const unsigned char *) data;
+    for (size_t i = 0; i < size; i++) {
+        ss << std::setw(2) << std::setfill('0') << std::hex << (int) bytes[i];
+    }
+    return ss.str();
+}
+
+// Reverse of hexBytes, false for an odd length or a non hex digit
+bool parseHexBytes(const std::string &hex, std::vector<unsigned char> &bytes) {
+    bytes.clear();
+    if (hex.size() % 2 != 0) {
+        return false;
+    }
+    for (size_t i = 0; i < hex.size(); i += 2) {
+        if (!isxdigit((unsigned char) hex[i]) || !isxdigit((unsigned char) hex[i + 1])) {
+            return false;
+        }
+        bytes.push_back((unsigned char) std::stoi(hex.substr(i, 2), nullptr, 16));
+    }
+    return true;
+}
+
+// The AVX-512 state REGDUMP lacks: zmm0-31 (zmm0-7 under x32dbg) and k0-7, moved out the way
+// /Register/Vector does with the vmovdqu and kmovq commands, all through one scratch page.
+// False when the cpu or x64dbg has no AVX-512
+bool readAvx512(std::vector<std::string> &zmm, std::vector<unsigned long long> &k) {
+    const int zmmCount = sizeof(duint) == 8 ? 32 : 8;
+    const duint kOffset = 32 * 64;
+    duint scratch = Script::Memory::RemoteAlloc(0, 0x1000);
+    if (!scratch) {
+        return false;
+    }
+    bool moved = true;
+    for (int i = 0; i < zmmCount && moved; i++) {
+        std::stringstream cmd;
+        cmd << "vmovdqu [0x" << std::hex << scratch + i * 64 << "], zmm" << std::dec << i;
+        moved = DbgCmdExecDirect(cmd.str().c_str());
+    }
+    for (int i = 0; i < 8 && moved; i++) {
+        std::stringstream cmd;
+        cmd << "kmovq [0x" << std::hex << scratch + kOffset + i * 8 << "], k" << std::dec << i;
+        moved = DbgCmdExecDirect(cmd.str().c_str());
+    }
+    std::vector<unsigned char> page(0x1000);
+    bool read = moved && DbgMemRead(scratch, page.data(), page.size());
+    Script::Memory::RemoteFree(scratch);
+    if (!read) {
+        return false;
+    }
+    for (int i = 0; i < zmmCount; i++) {
+        zmm.push_back(hexBytes(page.data() + i * 64, 64));
+    }
+    for (int i = 0; i < 8; i++) {
+        unsigned long long value = 0;
+        memcpy(&value, page.data() + kOffset + i * 8, 8);
+        k.push_back(value);
+    }
+    return true;
+}
+
+// The whole register context of DbgGetRegDumpEx, vectors and x87 registers as their memory image.
+// zmm and k come from readAvx512, avx512 is false and they are empty without it
+std::string registerContextJson(const REGDUMP &dump) {
+    const REGISTERCONTEXT &r = dump.regcontext;
+    std::stringstream ss;
+    ss << "{";
+    ss << "\"bits\":" << std::dec << sizeof(duint) * 8 << ",";
+    const std::pair<const char *, duint> scalars[] = {
+        {"cax", r.cax}, {"ccx", r.ccx}, {"cdx", r.cdx}, {"cbx", r.cbx},
+        {"csp", r.csp}, {"cbp", r.cbp}, {"csi", r.csi}, {"cdi", r.cdi},
+#ifdef _WIN64
+        {"r8", r.r8}, {"r9", r.r9}, {"r10", r.r10}, {"r11", r.r11},
+        {"r12", r.r12}, {"r13", r.r13}, {"r14", r.r14}, {"r15", r.r15},
+#endif
+        {"cip", r.cip}, {"cflags", r.eflags},
+        {"gs", r.gs}, {"fs", r.fs}, {"es", r.es}, {"ds", r.ds}, {"cs", r.cs}, {"ss", r.ss},
+        {"dr0", r.dr0}, {"dr1", r.dr1}, {"dr2", r.dr2}, {"dr3", r.dr3}, {"dr6", r.dr6}, {"dr7", r.dr7},
+        {"mxcsr", r.MxCsr},
+    };
+    for (const auto &scalar : scalars) {
+        ss << "\"" << scalar.first << "\":\"0x" << std::hex << scalar.second << "\",";
+    }
+    ss << "\"x87\":{";
+    ss << "\"control\":\"0x" << std::hex << r.x87fpu.ControlWord << "\",";
+    ss << "\"status\":\"0x" << std::hex << r.x87fpu.StatusWord << "\",";
+    ss << "\"tag\":\"0x" << std::hex << r.x87fpu.TagWord << "\",";
+    // RegisterArea is the FSAVE image, ST0 first
+    ss << "\"st\":[";
+    for (int i = 0; i < 8; i++) {
+        if (i > 0) ss << ",";
+        ss << "\"" << hexBytes(r.RegisterArea + i * 10, 10) << "\"";
+    }
+    ss << "]},";
+    const int vectors = sizeof(r.XmmRegisters) / sizeof(r.XmmRegisters[0]);
+    ss << "\"xmm\":[";
+    for (int i = 0; i < vectors; i++) {
+        if (i > 0) ss << ",";
+        ss << "\"" << hexBytes(&r.XmmRegisters[i], sizeof(XMMREGISTER)) << "\"";
+    }
+    ss << "],";
+    ss << "\"ymm\":[";
+    for (int i = 0; i < vectors; i++) {
+        if (i > 0) ss << ",";
+        ss << "\"" << hexBytes(&r.YmmRegisters[i], sizeof(YMMREGISTER)) << "\"";
+    }
+    ss << "],";
+    std::vector<std::string> zmm;
+    std::vector<unsigned long long> k;
+    bool avx512 = readAvx512(zmm, k);
+    ss << "\"avx512\":" << (avx512 ? "true" : "false") << ",";
+    ss << "\"zmm\":[";
+    for (size_t i = 0; i < zmm.size(); i++) {
+        if (i > 0) ss << ",";
+        ss << "\"" << zmm[i] << "\"";
+    }
+    ss << "],";
+    ss << "\"k\":[";
+    for (size_t i = 0; i < k.size(); i++) {
+        if (i > 0) ss << ",";
+        ss << "\"0x" << std::hex << k[i] << "\"";
+    }
+    ss << "]";
+    ss << "}";
+    return ss.str();
+}
+
+// Queue one event for every /Events subscriber, it runs on x64dbg's debug thread so it never
+// touches a socket. Subscribers that went away or fell MAX_EVENT_QUEUE events behind are dropped
+void publishEvent(const std::string &json) {
+    std::string message = "data: " + json + "\n\n";
+    std::lock_guard<std::mutex> lock(g_eventMutex);
+    for (auto it = g_eventClients.begin(); it != g_eventClients.end();) {
+        EventClient &client = **it;
+        if (!client.closed && client.queue.size() >= MAX_EVENT_QUEUE) {
+            closeEventClient(client);
+        }
+        if (client.closed) {
+            it = g_eventClients.erase(it);
+            continue;
+        }
+        client.queue.push_back(message);
+        client.ready.notify_one();
+        ++it;
+    }
+}
+
+// Subscribe clientSocket, header is the first thing its writer thread sends
+void addEventClient(SOCKET clientSocket, const std::string &header) {
+    auto client = std::make_shared<EventClient>();
+    client->socket = clientSocket;
+    client->queue.push_back(header);
+    {
+        std::lock_guard<std::mutex> lock(g_eventMutex);
+        g_eventClients.push_back(client);
+    }
+    std::thread(eventWriter, client).detach();
+}
+
+// Writer thread of one subscriber, the socket is blocking and sent to without holding the lock
+void eventWriter(std::shared_ptr<EventClient> client) {
+    std::unique_lock<std::mutex> lock(g_eventMutex);
+    while (true) {
+        client->ready.wait(lock, [&] { return client->closed || !client->queue.empty(); });
+        if (client->closed) {
+            break;
+        }
+        std::string message = std::move(client->queue.front());
+        client->queue.pop_front();
+        lock.unlock();
+        bool ok = send(client->socket, message.c_str(), (int) message.length(), 0) != SOCKET_ERROR;
+        lock.lock();
+        if (!ok) {
+            client->closed = true;
+            break;
+        }
+    }
+    g_eventClients.erase(std::remove(g_eventClients.begin(), g_eventClients.end(), client), g_eventClients.end());
+    lock.unlock();
+    closesocket(client->socket);
+}
+
+// Ask the writer thread to stop, g_eventMutex must be held. shutdown fails a send it is blocked in,
+// the writer closes the socket itself
+void closeEventClient(EventClient &client) {
+    client.closed = true;
+    client.queue.clear();
+    shutdown(client.socket, SD_BOTH);
+    client.ready.notify_one();
+}
+
+void closeEventClients() {
+    std::lock_guard<std::mutex> lock(g_eventMutex);
+    for (auto &client: g_eventClients) {
+        closeEventClient(*client);
+    }
+    g_eventClients.clear();
+}
+
+// JSON encoder of the debug events, see DebugEvent in schema.go
+void cbDebugEvent(CBTYPE cbType, void *callbackInfo) {
+    std::stringstream ss;
+    ss << "{";
+    switch (cbType) {
+        case CB_BREAKPOINT: {
+            auto info = (PLUG_CB_BREAKPOINT *) callbackInfo;
+            ss << "\"type\":\"breakpoint\",";
+            ss << "\"addr\":\"0x" << std::hex << info->breakpoint->addr << "\",";
+            ss << "\"threadId\":" << std::dec << DbgGetThreadId() << ",";
+            ss << "\"breakpoint\":" << breakpointJson(*info->breakpoint);
+            break;
+        }
+        case CB_EXCEPTION: {
+            auto info = (PLUG_CB_EXCEPTION *) callbackInfo;
+            ss << "\"type\":\"exception\",";
+            ss << "\"addr\":\"0x" << std::hex << (duint) info->Exception->ExceptionRecord.ExceptionAddress << "\",";
+            ss << "\"threadId\":" << std::dec << DbgGetThreadId() << ",";
+            ss << "\"code\":\"0x" << std::hex << info->Exception->ExceptionRecord.ExceptionCode << "\",";
+            ss << "\"firstChance\":" << (info->Exception->dwFirstChance ? "true" : "false");
+            break;
+        }
+        case CB_LOADDLL: {
+            auto info = (PLUG_CB_LOADDLL *) callbackInfo;
+            ss << "\"type\":\"loadDll\",";
+            ss << "\"addr\":\"0x" << std::hex << (duint) info->LoadDll->lpBaseOfDll << "\",";
+            ss << "\"module\":\"" << jsonEscape(info->modname ? info->modname : "") << "\"";
+            break;
+        }
+        case CB_UNLOADDLL: {
+            auto info = (PLUG_CB_UNLOADDLL *) callbackInfo;
+            char modname[MAX_MODULE_SIZE] = "";
+            DbgGetModuleAt((duint) info->UnloadDll->lpBaseOfDll, modname);
+            ss << "\"type\":\"unloadDll\",";
+            ss << "\"addr\":\"0x" << std::hex << (duint) info->UnloadDll->lpBaseOfDll << "\",";
+            ss << "\"module\":\"" << jsonEscape(modname) << "\"";
+            break;
+        }
+        case CB_CREATETHREAD: {
+            auto info = (PLUG_CB_CREATETHREAD *) callbackInfo;
+            ss << "\"type\":\"createThread\",";
+            ss << "\"addr\":\"0x" << std::hex << (duint) info->CreateThread->lpStartAddress << "\",";
+            ss << "\"threadId\":" << std::dec << info->dwThreadId;
+            break;
+        }
+        case CB_EXITTHREAD: {
+            auto info = (PLUG_CB_EXITTHREAD *) callbackInfo;
+            ss << "\"type\":\"exitThread\",";
+            ss << "\"threadId\":" << std::dec << info->dwThreadId << ",";
+            ss << "\"code\":\"0x" << std::hex << info->ExitThread->dwExitCode << "\"";
+            break;
+        }
+        case CB_EXITPROCESS: {
+            auto info = (PLUG_CB_EXITPROCESS *) callbackInfo;
+            ss << "\"type\":\"exitProcess\",";
+            ss << "\"code\":\"0x" << std::hex << info->ExitProcess->dwExitCode << "\"";
+            break;
+        }
+        case CB_PAUSEDEBUG:
+            ss << "\"type\":\"paused\",";
+            ss << "\"addr\":\"0x" << std::hex << Script::Register::GetCIP() << "\",";
+            ss << "\"threadId\":" << std::dec << DbgGetThreadId();
+            break;
+        case CB_RESUMEDEBUG:
+            ss << "\"type\":\"resumed\"";
+            break;
+        case CB_STEPPED:
+            ss << "\"type\":\"stepped\",";
+            ss << "\"addr\":\"0x" << std::hex << Script::Register::GetCIP() << "\",";
+            ss << "\"threadId\":" << std::dec << DbgGetThreadId();
+            break;
+        default:
+            return;
+    }
+    ss << "}";
+    publishEvent(ss.str());
+}
+
 // HTTP server thread function using standard Winsock
 DWORD WINAPI HttpServerThread(LPVOID lpParam) {
     WSADATA wsaData;
@@ -292,8 +970,14 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
 
             // Handle different endpoints
             try {
+                // Everything except command execution, the debug state queries and the symbol store
+                // setting needs a debuggee, answer 409 so clients can tell this apart from a bad address
+                if (!DbgIsDebugging() && path != "/ExecCommand" && path != "/IsDebugActive" && path != "/Is_Debugging" &&
+                    path != "/Events" && path != "/Symbol/StorePath") {
+                    sendHttpResponse(clientSocket, 409, "text/plain", "Not debugging");
+                }
                 // Unified command execution endpoint
-                if (path == "/ExecCommand") {
+                else if (path == "/ExecCommand") {
                     std::string cmd = queryParams["cmd"];
                     if (cmd.empty() && !body.empty()) {
                         cmd = body;
@@ -448,41 +1132,19 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         continue;
                     }
 
-                    // Convert register name to enum (simplified mapping)
+                    // x32dbg has no RAX or R8-R15, they are unknown there
                     Script::Register::RegisterEnum reg;
-                    if (regName == "EAX" || regName == "eax") reg = Script::Register::EAX;
-                    else if (regName == "EBX" || regName == "ebx") reg = Script::Register::EBX;
-                    else if (regName == "ECX" || regName == "ecx") reg = Script::Register::ECX;
-                    else if (regName == "EDX" || regName == "edx") reg = Script::Register::EDX;
-                    else if (regName == "ESI" || regName == "esi") reg = Script::Register::ESI;
-                    else if (regName == "EDI" || regName == "edi") reg = Script::Register::EDI;
-                    else if (regName == "EBP" || regName == "ebp") reg = Script::Register::EBP;
-                    else if (regName == "ESP" || regName == "esp") reg = Script::Register::ESP;
-                    else if (regName == "EIP" || regName == "eip") reg = Script::Register::EIP;
-#ifdef _WIN64
-                    else if (regName == "RAX" || regName == "rax") reg = Script::Register::RAX;
-                    else if (regName == "RBX" || regName == "rbx") reg = Script::Register::RBX;
-                    else if (regName == "RCX" || regName == "rcx") reg = Script::Register::RCX;
-                    else if (regName == "RDX" || regName == "rdx") reg = Script::Register::RDX;
-                    else if (regName == "RSI" || regName == "rsi") reg = Script::Register::RSI;
-                    else if (regName == "RDI" || regName == "rdi") reg = Script::Register::RDI;
-                    else if (regName == "RBP" || regName == "rbp") reg = Script::Register::RBP;
-                    else if (regName == "RSP" || regName == "rsp") reg = Script::Register::RSP;
-                    else if (regName == "RIP" || regName == "rip") reg = Script::Register::RIP;
-                    else if (regName == "R8" || regName == "r8") reg = Script::Register::R8;
-                    else if (regName == "R9" || regName == "r9") reg = Script::Register::R9;
-                    else if (regName == "R10" || regName == "r10") reg = Script::Register::R10;
-                    else if (regName == "R11" || regName == "r11") reg = Script::Register::R11;
-                    else if (regName == "R12" || regName == "r12") reg = Script::Register::R12;
-                    else if (regName == "R13" || regName == "r13") reg = Script::Register::R13;
-                    else if (regName == "R14" || regName == "r14") reg = Script::Register::R14;
-                    else if (regName == "R15" || regName == "r15") reg = Script::Register::R15;
-#endif
-                    else {
+                    if (!registerFromName(regName, reg)) {
                         sendHttpResponse(clientSocket, 400, "text/plain", "Unknown register");
                         continue;
                     }
 
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
                     duint value = Script::Register::Get(reg);
                     std::stringstream ss;
                     ss << "0x" << std::hex << value;
@@ -495,37 +1157,9 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         continue;
                     }
 
-                    // Convert register name to enum (same mapping as above)
+                    // x32dbg has no RAX or R8-R15, they are unknown there
                     Script::Register::RegisterEnum reg;
-                    if (regName == "EAX" || regName == "eax") reg = Script::Register::EAX;
-                    else if (regName == "EBX" || regName == "ebx") reg = Script::Register::EBX;
-                    else if (regName == "ECX" || regName == "ecx") reg = Script::Register::ECX;
-                    else if (regName == "EDX" || regName == "edx") reg = Script::Register::EDX;
-                    else if (regName == "ESI" || regName == "esi") reg = Script::Register::ESI;
-                    else if (regName == "EDI" || regName == "edi") reg = Script::Register::EDI;
-                    else if (regName == "EBP" || regName == "ebp") reg = Script::Register::EBP;
-                    else if (regName == "ESP" || regName == "esp") reg = Script::Register::ESP;
-                    else if (regName == "EIP" || regName == "eip") reg = Script::Register::EIP;
-#ifdef _WIN64
-                    else if (regName == "RAX" || regName == "rax") reg = Script::Register::RAX;
-                    else if (regName == "RBX" || regName == "rbx") reg = Script::Register::RBX;
-                    else if (regName == "RCX" || regName == "rcx") reg = Script::Register::RCX;
-                    else if (regName == "RDX" || regName == "rdx") reg = Script::Register::RDX;
-                    else if (regName == "RSI" || regName == "rsi") reg = Script::Register::RSI;
-                    else if (regName == "RDI" || regName == "rdi") reg = Script::Register::RDI;
-                    else if (regName == "RBP" || regName == "rbp") reg = Script::Register::RBP;
-                    else if (regName == "RSP" || regName == "rsp") reg = Script::Register::RSP;
-                    else if (regName == "RIP" || regName == "rip") reg = Script::Register::RIP;
-                    else if (regName == "R8" || regName == "r8") reg = Script::Register::R8;
-                    else if (regName == "R9" || regName == "r9") reg = Script::Register::R9;
-                    else if (regName == "R10" || regName == "r10") reg = Script::Register::R10;
-                    else if (regName == "R11" || regName == "r11") reg = Script::Register::R11;
-                    else if (regName == "R12" || regName == "r12") reg = Script::Register::R12;
-                    else if (regName == "R13" || regName == "r13") reg = Script::Register::R13;
-                    else if (regName == "R14" || regName == "r14") reg = Script::Register::R14;
-                    else if (regName == "R15" || regName == "r15") reg = Script::Register::R15;
-#endif
-                    else {
+                    if (!registerFromName(regName, reg)) {
                         sendHttpResponse(clientSocket, 400, "text/plain", "Unknown register");
                         continue;
                     }
@@ -542,9 +1176,179 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         continue;
                     }
 
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
                     bool success = Script::Register::Set(reg, value);
                     sendHttpResponse(clientSocket, success ? 200 : 500, "text/plain",
                                      success ? "Register set successfully" : "Failed to set register");
+                } else if (path == "/Register/Context") {
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
+                    REGDUMP dump = {};
+                    if (!DbgGetRegDumpEx(&dump, sizeof(dump))) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Failed to read registers");
+                        continue;
+                    }
+                    sendHttpResponse(clientSocket, 200, "application/json", registerContextJson(dump));
+                } else if (path == "/Register/Vector") {
+                    // xmm and ymm registers REGDUMP has are read from it and written the way of x64dbg's
+                    // register view, DbgValToString of "_" and the register name. zmm0-31, k0-7 and the
+                    // upper xmm/ymm16-31 are AVX-512 state REGDUMP lacks, they go through the vmovdqu and
+                    // kmovq commands and a scratch page. The body is the new value, reads send the image back
+                    std::string regName = queryParams["register"];
+                    std::transform(regName.begin(), regName.end(), regName.begin(), ::tolower);
+                    std::string prefix = regName.substr(0, regName.find_first_of("0123456789"));
+                    std::string mov;
+                    duint size = 0;
+                    int count = 32;
+                    if (prefix == "xmm") {
+                        mov = "movdqu", size = 16;
+                    } else if (prefix == "ymm") {
+                        mov = "vmovdqu", size = 32;
+                    } else if (prefix == "zmm") {
+                        mov = "vmovdqu", size = 64;
+                    } else if (prefix == "k") {
+                        mov = "kmovq", size = 8, count = 8;
+                    }
+                    int index = -1;
+                    try {
+                        if (size != 0 && prefix.size() < regName.size()) {
+                            index = std::stoi(regName.substr(prefix.size()), nullptr, 10);
+                        }
+                    } catch (const std::exception &e) {
+                    }
+                    if (index < 0 || index >= count) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Unknown register");
+                        continue;
+                    }
+                    std::vector<unsigned char> value;
+                    if (!body.empty() && (!parseHexBytes(body, value) || value.size() != size)) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid value format");
+                        continue;
+                    }
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
+                    REGDUMP dump = {};
+                    if (!DbgGetRegDumpEx(&dump, sizeof(dump))) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Failed to read registers");
+                        continue;
+                    }
+                    // 16 under x64dbg, x32dbg has xmm0-7
+                    const int dumped = sizeof(dump.regcontext.XmmRegisters) / sizeof(dump.regcontext.XmmRegisters[0]);
+                    if ((prefix == "xmm" || prefix == "ymm") && index < dumped) {
+                        const unsigned char *current = prefix == "xmm"
+                                                       ? (const unsigned char *) &dump.regcontext.XmmRegisters[index]
+                                                       : (const unsigned char *) &dump.regcontext.YmmRegisters[index];
+                        if (body.empty()) {
+                            sendHttpResponse(clientSocket, 200, "text/plain", hexBytes(current, size));
+                        } else {
+                            std::string name = "_" + regName;
+                            std::transform(name.begin(), name.end(), name.begin(), ::toupper);
+                            bool success = DbgValToString(name.c_str(), (duint) value.data());
+                            sendHttpResponse(clientSocket, success ? 200 : 500, "text/plain",
+                                             success ? "Register set successfully" : "Failed to set register");
+                        }
+                    } else if ((prefix == "xmm" || prefix == "ymm") && sizeof(duint) == 4) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Unknown register");
+                    } else {
+                        duint scratch = Script::Memory::RemoteAlloc(0, 0x1000);
+                        if (!scratch) {
+                            sendHttpResponse(clientSocket, 500, "text/plain", "Failed to allocate scratch memory");
+                            continue;
+                        }
+                        std::stringstream cmd;
+                        if (!value.empty()) {
+                            DbgMemWrite(scratch, value.data(), size);
+                            cmd << mov << " " << regName << ", [0x" << std::hex << scratch << "]";
+                        } else {
+                            value.resize(size);
+                            cmd << mov << " [0x" << std::hex << scratch << "], " << regName;
+                        }
+                        bool moved = DbgCmdExecDirect(cmd.str().c_str());
+                        bool read = moved && (!body.empty() || DbgMemRead(scratch, value.data(), size));
+                        Script::Memory::RemoteFree(scratch);
+                        if (!moved) {
+                            // the cpu or x64dbg lacks AVX-512
+                            sendHttpResponse(clientSocket, 501, "text/plain", "AVX-512 register not available: " + cmd.str());
+                        } else if (!read) {
+                            sendHttpResponse(clientSocket, 500, "text/plain", "Failed to read scratch memory");
+                        } else {
+                            sendHttpResponse(clientSocket, 200, "text/plain",
+                                             body.empty() ? hexBytes(value.data(), size) : "Register set successfully");
+                        }
+                    }
+                } else if (path == "/Register/FPU") {
+                    // st0-7, x87cw, x87sw and mxcsr as their memory image, writes take the way of
+                    // x64dbg's register view: DbgValToString of "_" and the FPU register name
+                    std::string regName = queryParams["register"];
+                    std::transform(regName.begin(), regName.end(), regName.begin(), ::tolower);
+                    duint size = 0;
+                    int st = -1;
+                    if (regName.size() == 3 && regName.compare(0, 2, "st") == 0 && regName[2] >= '0' && regName[2] <= '7') {
+                        st = regName[2] - '0', size = 10;
+                    } else if (regName == "x87cw" || regName == "x87sw") {
+                        size = 2;
+                    } else if (regName == "mxcsr") {
+                        size = 4;
+                    }
+                    if (size == 0) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Unknown register");
+                        continue;
+                    }
+                    std::vector<unsigned char> value;
+                    if (!body.empty() && (!parseHexBytes(body, value) || value.size() != size)) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid value format");
+                        continue;
+                    }
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
+                    REGDUMP dump = {};
+                    if (!DbgGetRegDumpEx(&dump, sizeof(dump))) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Failed to read registers");
+                        continue;
+                    }
+                    const REGISTERCONTEXT &r = dump.regcontext;
+                    if (body.empty()) {
+                        if (st >= 0) {
+                            value.assign(r.RegisterArea + st * 10, r.RegisterArea + st * 10 + 10);
+                        } else {
+                            duint current = regName == "x87cw" ? r.x87fpu.ControlWord
+                                            : regName == "x87sw" ? r.x87fpu.StatusWord : r.MxCsr;
+                            value.assign((unsigned char *) &current, (unsigned char *) &current + size);
+                        }
+                        sendHttpResponse(clientSocket, 200, "text/plain", hexBytes(value.data(), size));
+                    } else {
+                        bool success;
+                        if (st >= 0) {
+                            // ST(i) is the physical register (TOP + i) mod 8
+                            int top = (r.x87fpu.StatusWord >> 11) & 7;
+                            std::string name = "_x87r" + std::to_string((top + st) & 7);
+                            success = DbgValToString(name.c_str(), (duint) value.data());
+                        } else {
+                            duint newValue = 0;
+                            memcpy(&newValue, value.data(), size);
+                            const char *name = regName == "x87cw" ? "_x87ControlWord" : regName == "x87sw" ? "_x87StatusWord" : "_MxCsr";
+                            success = DbgValToString(name, newValue);
+                        }
+                        sendHttpResponse(clientSocket, success ? 200 : 500, "text/plain",
+                                         success ? "Register set successfully" : "Failed to set register");
+                    }
                 }
 
                     // =============================================================================
@@ -586,12 +1390,18 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         continue;
                     }
 
-                    std::stringstream ss;
-                    for (duint i = 0; i < sizeRead; i++) {
-                        ss << std::setw(2) << std::setfill('0') << std::hex << (int) buffer[i];
-                    }
+                    // encoding=binary sends the raw bytes, half the size of hex
+                    if (queryParams["encoding"] == "binary") {
+                        sendHttpResponse(clientSocket, 200, "application/octet-stream",
+                                         std::string((const char *) buffer.data(), sizeRead));
+                    } else {
+                        std::stringstream ss;
+                        for (duint i = 0; i < sizeRead; i++) {
+                            ss << std::setw(2) << std::setfill('0') << std::hex << (int) buffer[i];
+                        }
 
-                    sendHttpResponse(clientSocket, 200, "text/plain", ss.str());
+                        sendHttpResponse(clientSocket, 200, "text/plain", ss.str());
+                    }
                 } else if (path == "/Memory/Write") {
                     std::string addrStr = queryParams["addr"];
                     std::string dataStr = !body.empty() ? body : queryParams["data"];
@@ -674,6 +1484,60 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                     std::stringstream ss;
                     ss << "0x" << std::hex << protect;
                     sendHttpResponse(clientSocket, 200, "text/plain", ss.str());
+                } else if (path == "/Memory/Alloc") {
+                    // runs the alloc command so x64dbg tracks the allocation and answers its $result directly
+                    std::string sizeStr = queryParams["size"];
+                    std::string addrStr = queryParams["addr"];
+                    if (sizeStr.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing size parameter");
+                        continue;
+                    }
+
+                    duint size = 0;
+                    duint addr = 0;
+                    try {
+                        size = std::stoull(sizeStr, nullptr, 10);
+                        if (!addrStr.empty()) {
+                            addr = std::stoull(addrStr.substr(0, 2) == "0x" ? addrStr.substr(2) : addrStr, nullptr, 16);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid address or size format");
+                        continue;
+                    }
+
+                    std::stringstream cmd;
+                    cmd << "alloc 0x" << std::hex << size;
+                    if (addr != 0) {
+                        cmd << ", 0x" << std::hex << addr;
+                    }
+                    duint result = 0;
+                    if (DbgCmdExecDirect(cmd.str().c_str())) {
+                        result = DbgValFromString("$result");
+                    }
+                    if (result == 0) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Failed to allocate memory");
+                        continue;
+                    }
+
+                    std::stringstream ss;
+                    ss << "0x" << std::hex << result;
+                    sendHttpResponse(clientSocket, 200, "text/plain", ss.str());
+                } else if (path == "/Memory/Map") {
+                    MEMMAP memmap;
+                    memset(&memmap, 0, sizeof(memmap));
+                    DbgMemMap(&memmap);
+
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int i = 0; i < memmap.count; i++) {
+                        if (i > 0) ss << ",";
+                        ss << memoryPageJson(memmap.page[i]);
+                    }
+                    ss << "]";
+                    if (memmap.page) {
+                        BridgeFree(memmap.page);
+                    }
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
                 }
 
                     // =============================================================================
@@ -862,6 +1726,90 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                     std::stringstream ss;
                     ss << "0x" << std::hex << value;
                     sendHttpResponse(clientSocket, 200, "text/plain", ss.str());
+                } else if (path == "/Stack/CallStack") {
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
+                    DBGCALLSTACK callstack = {};
+                    DbgFunctions()->GetCallStack(&callstack);
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int i = 0; i < callstack.total; i++) {
+                        const DBGCALLSTACKENTRY &entry = callstack.entries[i];
+                        NearestSymbol nearest;
+                        nearestSymbol(entry.from, nearest);
+                        if (i > 0) ss << ",";
+                        ss << "{";
+                        ss << "\"address\":\"0x" << std::hex << entry.addr << "\",";
+                        ss << "\"from\":\"0x" << std::hex << entry.from << "\",";
+                        ss << "\"to\":\"0x" << std::hex << entry.to << "\",";
+                        ss << "\"module\":\"" << jsonEscape(nearest.module) << "\",";
+                        ss << "\"symbol\":\"" << jsonEscape(nearest.name) << "\",";
+                        ss << "\"displacement\":\"0x" << std::hex << (nearest.found ? entry.from - nearest.best : 0) << "\",";
+                        ss << "\"comment\":\"" << jsonEscape(entry.comment) << "\"";
+                        ss << "}";
+                    }
+                    ss << "]";
+                    if (callstack.entries) {
+                        BridgeFree(callstack.entries);
+                    }
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                } else if (path == "/Stack/Dump") {
+                    int count = 32;
+                    try {
+                        if (!queryParams["count"].empty()) {
+                            count = std::stoi(queryParams["count"]);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid count format");
+                        continue;
+                    }
+                    if (count <= 0 || count > 0x10000) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid count");
+                        continue;
+                    }
+                    ThreadSwitch threadSwitch(queryParams["thread"]);
+                    if (!threadSwitch.ok) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Thread not found");
+                        continue;
+                    }
+
+                    // Like printstack: every pointer sized slot from csp up, with the comment of
+                    // the stack view and the symbol the value points to
+                    duint csp = Script::Register::GetCSP();
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int i = 0; i < count; i++) {
+                        duint slot = csp + i * sizeof(duint);
+                        duint value = 0;
+                        if (!DbgMemRead(slot, &value, sizeof(value))) {
+                            break;
+                        }
+                        STACK_COMMENT comment = {};
+                        DbgStackCommentGet(slot, &comment);
+                        std::string symbol;
+                        NearestSymbol nearest;
+                        if (nearestSymbol(value, nearest)) {
+                            std::stringstream name;
+                            name << nearest.module << "!" << nearest.name;
+                            if (value != nearest.best) {
+                                name << "+0x" << std::hex << value - nearest.best;
+                            }
+                            symbol = name.str();
+                        }
+                        if (i > 0) ss << ",";
+                        ss << "{";
+                        ss << "\"address\":\"0x" << std::hex << slot << "\",";
+                        ss << "\"value\":\"0x" << std::hex << value << "\",";
+                        ss << "\"comment\":\"" << jsonEscape(comment.comment) << "\",";
+                        ss << "\"symbol\":\"" << jsonEscape(symbol) << "\"";
+                        ss << "}";
+                    }
+                    ss << "]";
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
                 } else if (path == "/Disasm/GetInstruction") {
                     std::string addrStr = queryParams["addr"];
                     if (addrStr.empty()) {
@@ -889,7 +1837,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                     std::stringstream ss;
                     ss << "{";
                     ss << "\"address\":\"0x" << std::hex << addr << "\",";
-                    ss << "\"instruction\":\"" << instr.instruction << "\",";
+                    ss << "\"instruction\":\"" << jsonEscape(instr.instruction) << "\",";
                     ss << "\"size\":" << std::dec << instr.instr_size;
                     ss << "}";
 
@@ -940,7 +1888,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
 
                             ss << "{";
                             ss << "\"address\":\"0x" << std::hex << currentAddr << "\",";
-                            ss << "\"instruction\":\"" << instr.instruction << "\",";
+                            ss << "\"instruction\":\"" << jsonEscape(instr.instruction) << "\",";
                             ss << "\"size\":" << std::dec << instr.instr_size;
                             ss << "}";
 
@@ -966,7 +1914,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                     std::stringstream ss;
                     ss << "{";
                     ss << "\"rip\":\"0x" << std::hex << rip << "\",";
-                    ss << "\"instruction\":\"" << instr.instruction << "\",";
+                    ss << "\"instruction\":\"" << jsonEscape(instr.instruction) << "\",";
                     ss << "\"size\":" << std::dec << instr.instr_size;
                     ss << "}";
 
@@ -987,7 +1935,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                     ss << "{";
                     ss << "\"step_result\":\"Step in executed\",";
                     ss << "\"rip\":\"0x" << std::hex << rip << "\",";
-                    ss << "\"instruction\":\"" << instr.instruction << "\",";
+                    ss << "\"instruction\":\"" << jsonEscape(instr.instruction) << "\",";
                     ss << "\"size\":" << std::dec << instr.instr_size;
                     ss << "}";
 
@@ -1084,6 +2032,72 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         sendHttpResponse(clientSocket, 404, "text/plain", "Pattern not found");
                     }
                 }
+                else if (path == "/Pattern/FindAll") {
+                    std::string startStr = queryParams["start"];
+                    std::string sizeStr = queryParams["size"];
+                    std::string pattern = queryParams["pattern"];
+                    std::string maxStr = queryParams["max"];
+
+                    if (startStr.empty() || sizeStr.empty() || pattern.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing start, size, or pattern parameter");
+                        continue;
+                    }
+
+                    duint start = 0, size = 0, maxResults = 5000;
+                    try {
+                        if (startStr.substr(0, 2) == "0x") {
+                            start = std::stoull(startStr.substr(2), nullptr, 16);
+                        } else {
+                            start = std::stoull(startStr, nullptr, 16);
+                        }
+                        size = std::stoull(sizeStr, nullptr, 10);
+                        if (!maxStr.empty()) {
+                            maxResults = std::stoull(maxStr, nullptr, 10);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid start, size or max format");
+                        continue;
+                    }
+                    if (size > 64 * 1024 * 1024) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Size too large");
+                        continue;
+                    }
+
+                    // Read page by page and search each readable run on its own, so an unreadable
+                    // page neither fails the scan nor produces matches on zeroes.
+                    std::vector<unsigned char> data(size);
+                    std::vector<duint> hits;
+                    auto scanRun = [&](duint from, duint to) {
+                        duint offset = from;
+                        while (offset < to && hits.size() < maxResults) {
+                            duint found = Script::Pattern::Find(data.data() + offset, to - offset, pattern.c_str());
+                            if (found == duint(-1)) {
+                                break;
+                            }
+                            hits.push_back(start + offset + found);
+                            offset += found + 1;
+                        }
+                    };
+                    duint runStart = 0;
+                    for (duint offset = 0; offset < size;) {
+                        duint n = (std::min)(size - offset, 0x1000 - ((start + offset) & 0xFFF));
+                        if (!DbgMemRead(start + offset, data.data() + offset, n)) {
+                            scanRun(runStart, offset);
+                            runStart = offset + n;
+                        }
+                        offset += n;
+                    }
+                    scanRun(runStart, size);
+
+                    std::stringstream ss;
+                    ss << "[";
+                    for (size_t i = 0; i < hits.size(); i++) {
+                        if (i > 0) ss << ",";
+                        ss << "\"0x" << std::hex << hits[i] << "\"";
+                    }
+                    ss << "]";
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                }
 
                     // =============================================================================
                     // MISC API ENDPOINTS
@@ -1161,7 +2175,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                            << "\"}";
                         sendHttpResponse(clientSocket, 200, "application/json", ss.str());
                     }
-                } else if (path == "/GetModuleList") {
+                } else if (path == "/GetModuleList" || path == "/Module/GetList") {
                     // Create a list to store the module information
                     ListInfo moduleList;
 
@@ -1183,14 +2197,7 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                             if (i > 0) jsonResponse << ",";
 
                             // Add module info as JSON object
-                            jsonResponse << "{";
-                            jsonResponse << "\"name\":\"" << modules[i].name << "\",";
-                            jsonResponse << "\"base\":\"0x" << std::hex << modules[i].base << "\",";
-                            jsonResponse << "\"size\":\"0x" << std::hex << modules[i].size << "\",";
-                            jsonResponse << "\"entry\":\"0x" << std::hex << modules[i].entry << "\",";
-                            jsonResponse << "\"sectionCount\":" << std::dec << modules[i].sectionCount << ",";
-                            jsonResponse << "\"path\":\"" << modules[i].path << "\"";
-                            jsonResponse << "}";
+                            jsonResponse << moduleInfoJson(modules[i]);
                         }
 
                         jsonResponse << "]";
@@ -1201,6 +2208,372 @@ DWORD WINAPI HttpServerThread(LPVOID lpParam) {
                         // Send the response
                         sendHttpResponse(clientSocket, 200, "application/json", jsonResponse.str());
                     }
+                }
+                    // Module functions, the module is picked by addr, name or defaults to the main module
+                else if (path == "/Module/Info" || path == "/Module/SectionList" || path == "/Module/Section" ||
+                         path == "/Module/Exports" || path == "/Module/Imports") {
+                    Script::Module::ModuleInfo info;
+                    int status = resolveModule(queryParams, info);
+                    if (status != 200) {
+                        sendHttpResponse(clientSocket, status, "text/plain",
+                                         status == 400 ? "Invalid address format"
+                                         : status == 422 ? "Missing addr, name or main parameter"
+                                         : "Module not found");
+                    } else if (path == "/Module/Info") {
+                        sendHttpResponse(clientSocket, 200, "application/json", moduleInfoJson(info));
+                    } else if (path == "/Module/Section") {
+                        std::string numberStr = queryParams["number"];
+                        int number = -1;
+                        try {
+                            number = std::stoi(numberStr, nullptr, 10);
+                        } catch (const std::exception &e) {
+                        }
+                        Script::Module::ModuleSectionInfo section;
+                        if (numberStr.empty()) {
+                            sendHttpResponse(clientSocket, 400, "text/plain", "Missing number parameter");
+                        } else if (number < 0) {
+                            sendHttpResponse(clientSocket, 400, "text/plain", "Invalid number format");
+                        } else if (!Script::Module::SectionFromAddr(info.base, number, &section)) {
+                            sendHttpResponse(clientSocket, 404, "text/plain", "Section not found");
+                        } else {
+                            sendHttpResponse(clientSocket, 200, "application/json", moduleSectionJson(section));
+                        }
+                    } else {
+                        ListInfo list;
+                        bool success = false;
+                        std::stringstream ss;
+                        ss << "[";
+                        if (path == "/Module/SectionList") {
+                            success = Script::Module::SectionListFromAddr(info.base, &list);
+                            auto *items = (Script::Module::ModuleSectionInfo *) list.data;
+                            for (int i = 0; success && i < list.count; i++) {
+                                ss << (i > 0 ? "," : "") << moduleSectionJson(items[i]);
+                            }
+                        } else if (path == "/Module/Exports") {
+                            success = Script::Module::GetExports(&info, &list);
+                            auto *items = (Script::Module::ModuleExport *) list.data;
+                            for (int i = 0; success && i < list.count; i++) {
+                                ss << (i > 0 ? "," : "") << moduleExportJson(items[i]);
+                            }
+                        } else {
+                            success = Script::Module::GetImports(&info, &list);
+                            auto *items = (Script::Module::ModuleImport *) list.data;
+                            for (int i = 0; success && i < list.count; i++) {
+                                ss << (i > 0 ? "," : "") << moduleImportJson(items[i]);
+                            }
+                        }
+                        ss << "]";
+                        if (success) {
+                            BridgeFree(list.data);
+                            sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                        } else {
+                            sendHttpResponse(clientSocket, 500, "text/plain", "Failed to read module " + path.substr(8));
+                        }
+                    }
+                }
+                    // Breakpoint functions, structured form of the bplist command
+                else if (path == "/Breakpoint/List") {
+                    // type is a BPXTYPE mask, all kinds when missing
+                    const int allTypes = bp_normal | bp_hardware | bp_memory | bp_dll | bp_exception;
+                    std::string typeStr = queryParams["type"];
+                    int type = allTypes;
+                    if (!typeStr.empty()) {
+                        try {
+                            type = std::stoi(typeStr, nullptr, 10);
+                        } catch (const std::exception &e) {
+                            type = 0;
+                        }
+                    }
+                    if (type <= 0 || (type & ~allTypes) != 0) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid type");
+                        continue;
+                    }
+
+                    BPMAP bpmap;
+                    int count = DbgGetBpList((BPXTYPE) type, &bpmap);
+
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int i = 0; i < count; i++) {
+                        if (i > 0) ss << ",";
+                        ss << breakpointJson(bpmap.bp[i]);
+                    }
+                    ss << "]";
+                    if (count > 0) {
+                        BridgeFree(bpmap.bp);
+                    }
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                }
+                else if (path == "/Breakpoint/SetOptions") {
+                    // body: option commands one per line, an empty line, then the commands restoring the old options.
+                    // Requests are served one at a time, so no other command runs in between; when one option
+                    // command fails the rollback commands run and the breakpoint is left as it was.
+                    std::vector<std::string> apply, rollback;
+                    std::vector<std::string> *current = &apply;
+                    std::istringstream lines(body);
+                    std::string line;
+                    while (std::getline(lines, line)) {
+                        if (!line.empty() && line.back() == '\r') line.pop_back();
+                        if (line.empty()) {
+                            current = &rollback;
+                        } else {
+                            current->push_back(line);
+                        }
+                    }
+
+                    std::string failed;
+                    for (const auto &cmd: apply) {
+                        if (!DbgCmdExecDirect(cmd.c_str())) {
+                            failed = cmd;
+                            break;
+                        }
+                    }
+                    if (failed.empty()) {
+                        sendHttpResponse(clientSocket, 200, "text/plain", "Breakpoint options set");
+                    } else {
+                        for (const auto &cmd: rollback) {
+                            DbgCmdExecDirect(cmd.c_str());
+                        }
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Command failed, options rolled back: " + failed);
+                    }
+                }
+                else if (path == "/Events") {
+                    // Server-Sent Events, the connection stays open and cbDebugEvent writes to it
+                    std::stringstream header;
+                    header << "HTTP/1.1 200 OK\r\n";
+                    header << "Content-Type: text/event-stream\r\n";
+                    header << "Cache-Control: no-cache\r\n";
+                    header << "X-MCPx64dbg-Schema: " << MCP_SCHEMA_VERSION << "\r\n";
+                    header << "X-MCPx64dbg-Bits: " << sizeof(duint) * 8 << "\r\n";
+                    header << "Connection: keep-alive\r\n";
+                    header << "\r\n";
+                    std::string headerStr = header.str();
+
+                    // accepted sockets inherit the non-blocking mode of the server socket, the writer
+                    // thread of the subscriber wants a blocking one
+                    u_long blocking = 0;
+                    ioctlsocket(clientSocket, FIONBIO, &blocking);
+
+                    addEventClient(clientSocket, headerStr);
+                    continue;
+                }
+                else if (path == "/Thread/List") {
+                    THREADLIST list;
+                    memset(&list, 0, sizeof(list));
+                    DbgGetThreadList(&list);
+
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int i = 0; i < list.count; i++) {
+                        if (i > 0) ss << ",";
+                        ss << threadJson(list.list[i], i == list.CurrentThread);
+                    }
+                    ss << "]";
+                    if (list.list) {
+                        BridgeFree(list.list);
+                    }
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                }
+                else if (path == "/Thread/Create") {
+                    // the thread only shows up in the thread list once x64dbg processed its creation
+                    // event, which takes the debuggee running, createthread leaves its id in $result
+                    std::string entryStr = queryParams["entry"];
+                    std::string argStr = queryParams["arg"];
+                    if (entryStr.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing entry parameter");
+                        continue;
+                    }
+
+                    duint entry = 0;
+                    duint arg = 0;
+                    try {
+                        entry = std::stoull(entryStr.substr(0, 2) == "0x" ? entryStr.substr(2) : entryStr, nullptr, 16);
+                        if (!argStr.empty()) {
+                            arg = std::stoull(argStr.substr(0, 2) == "0x" ? argStr.substr(2) : argStr, nullptr, 16);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid entry or arg format");
+                        continue;
+                    }
+
+                    std::stringstream cmd;
+                    cmd << "createthread 0x" << std::hex << entry << ", 0x" << arg;
+                    duint threadId = 0;
+                    if (DbgCmdExecDirect(cmd.str().c_str())) {
+                        threadId = DbgValFromString("$result");
+                    }
+                    if (threadId == 0) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Failed to create thread");
+                        continue;
+                    }
+                    sendHttpResponse(clientSocket, 200, "text/plain", std::to_string(threadId));
+                }
+                else if (path == "/Xref/Get") {
+                    std::string addrStr = queryParams["addr"];
+                    if (addrStr.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing address parameter");
+                        continue;
+                    }
+
+                    duint addr = 0;
+                    try {
+                        if (addrStr.substr(0, 2) == "0x") {
+                            addr = std::stoull(addrStr.substr(2), nullptr, 16);
+                        } else {
+                            addr = std::stoull(addrStr, nullptr, 16);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid address format");
+                        continue;
+                    }
+
+                    XREF_INFO info = {};
+                    std::stringstream ss;
+                    ss << "[";
+                    if (DbgXrefGet(addr, &info)) {
+                        for (duint i = 0; i < info.refcount; i++) {
+                            const XREF_RECORD &record = info.references[i];
+                            const char *type = record.type == XREF_CALL ? "call" : record.type == XREF_JMP ? "jmp" : record.type == XREF_DATA ? "data" : "";
+                            DISASM_INSTR instr;
+                            DbgDisasmAt(record.addr, &instr);
+                            if (i > 0) ss << ",";
+                            ss << "{\"address\":\"0x" << std::hex << record.addr << "\",";
+                            ss << "\"type\":\"" << type << "\",";
+                            ss << "\"disassembly\":\"" << jsonEscape(instr.instruction) << "\",";
+                            ss << "\"text\":\"\"}";
+                        }
+                        if (info.references) {
+                            BridgeFree(info.references);
+                        }
+                    }
+                    ss << "]";
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                }
+                else if (path == "/References") {
+                    // Run a reference search (reffind, refstr, modcallfind, ...) and read the rows it
+                    // put into the reference view back: address, disassembly and the last column.
+                    std::string cmd = queryParams["command"];
+                    if (cmd.empty() && !body.empty()) {
+                        cmd = body;
+                    }
+                    if (cmd.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing command parameter");
+                        continue;
+                    }
+                    if (!DbgCmdExecDirect(cmd.c_str())) {
+                        sendHttpResponse(clientSocket, 500, "text/plain", "Command execution failed");
+                        continue;
+                    }
+
+                    auto cell = [](int row, int col) {
+                        std::string text;
+                        char *content = GuiReferenceGetCellContent(row, col);
+                        if (content) {
+                            text = content;
+                            BridgeFree(content);
+                        }
+                        return text;
+                    };
+                    int rows = GuiReferenceGetRowCount();
+                    std::stringstream ss;
+                    ss << "[";
+                    for (int row = 0; row < rows; row++) {
+                        duint addr = 0;
+                        try {
+                            addr = std::stoull(cell(row, 0), nullptr, 16);
+                        } catch (const std::exception &) {
+                            continue;
+                        }
+                        std::string text = cell(row, 3);
+                        if (text.empty()) {
+                            text = cell(row, 2);
+                        }
+                        if (ss.tellp() > 1) ss << ",";
+                        ss << "{\"address\":\"0x" << std::hex << addr << "\",";
+                        ss << "\"type\":\"\",";
+                        ss << "\"disassembly\":\"" << jsonEscape(cell(row, 1)) << "\",";
+                        ss << "\"text\":\"" << jsonEscape(text) << "\"}";
+                    }
+                    ss << "]";
+                    sendHttpResponse(clientSocket, 200, "application/json", ss.str());
+                }
+                else if (path == "/Symbol/FromAddress") {
+                    std::string addrStr = queryParams["addr"];
+                    if (addrStr.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing address parameter");
+                        continue;
+                    }
+
+                    duint addr = 0;
+                    try {
+                        if (addrStr.substr(0, 2) == "0x") {
+                            addr = std::stoull(addrStr.substr(2), nullptr, 16);
+                        } else {
+                            addr = std::stoull(addrStr, nullptr, 16);
+                        }
+                    } catch (const std::exception &e) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Invalid address format");
+                        continue;
+                    }
+
+                    std::string json;
+                    int status = symbolAt(addr, json);
+                    sendHttpResponse(clientSocket, status, status == 200 ? "application/json" : "text/plain", json);
+                }
+                else if (path == "/Symbol/Resolve") {
+                    std::string name = queryParams["name"];
+                    if (name.empty()) {
+                        sendHttpResponse(clientSocket, 400, "text/plain", "Missing name parameter");
+                        continue;
+                    }
+                    if (!DbgIsValidExpression(name.c_str())) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Symbol not found");
+                        continue;
+                    }
+
+                    std::string json;
+                    int status = symbolAt(DbgValFromString(name.c_str()), json);
+                    sendHttpResponse(clientSocket, status, status == 200 ? "application/json" : "text/plain", json);
+                }
+                else if (path == "/Symbol/List") {
+                    std::string module = queryParams["module"];
+                    duint base = module.empty() ? 0 : DbgModBaseFromName(module.c_str());
+                    if (base == 0) {
+                        sendHttpResponse(clientSocket, 404, "text/plain", "Module not found");
+                        continue;
+                    }
+
+                    struct SymbolList {
+                        std::string module;
+                        duint base;
+                        std::stringstream ss;
+                        bool first;
+                    } list;
+                    list.module = module;
+                    list.base = base;
+                    list.first = true;
+                    list.ss << "[";
+                    DbgSymbolEnum(base, [](const SYMBOLPTR *symbol, void *user) {
+                        SymbolList *list = (SymbolList *) user;
+                        SYMBOLINFOCPP info;
+                        DbgGetSymbolInfo(symbol, &info);
+                        if (!list->first) list->ss << ",";
+                        list->first = false;
+                        list->ss << symbolJson(info, list->module, list->base, 0);
+                        return true;
+                    }, &list);
+                    list.ss << "]";
+                    sendHttpResponse(clientSocket, 200, "application/json", list.ss.str());
+                }
+                else if (path == "/Symbol/StorePath") {
+                    // GET reads, a body sets the local store symdownload saves pdbs to
+                    if (!body.empty()) {
+                        BridgeSettingSet("Symbols", "CachePath", body.c_str());
+                        BridgeSettingFlush();
+                    }
+                    std::vector<char> value(MAX_SETTING_SIZE, 0);
+                    BridgeSettingGet("Symbols", "CachePath", value.data());
+                    sendHttpResponse(clientSocket, 200, "text/plain", value.data());
                 }
                     // Memory Access Functions (Legacy endpoints for compatibility)
                 else if (path == "/MemRead") {
@@ -1332,12 +2705,29 @@ std::string readHttpRequest(SOCKET clientSocket) {
     u_long mode = 0;
     ioctlsocket(clientSocket, FIONBIO, &mode);
 
-    // Receive data
-    bytesReceived = recv(clientSocket, buffer, sizeof(buffer) - 1, 0);
+    // Receive until the headers are complete, then until Content-Length bytes of body arrived,
+    // large payloads (Memory/Write data, long commands) are sent as POST body and span several recv calls
+    size_t headersEnd = std::string::npos;
+    size_t contentLength = 0;
+    while ((bytesReceived = recv(clientSocket, buffer, sizeof(buffer), 0)) > 0) {
+        request.append(buffer, bytesReceived);
+
+        if (headersEnd == std::string::npos) {
+            headersEnd = request.find("\r\n\r\n");
+            if (headersEnd == std::string::npos) {
+                continue;
+            }
+            std::string headers = request.substr(0, headersEnd);
+            std::transform(headers.begin(), headers.end(), headers.begin(), ::tolower);
+            size_t pos = headers.find("content-length:");
+            if (pos != std::string::npos) {
+                contentLength = std::strtoull(headers.c_str() + pos + 15, nullptr, 10);
+            }
+        }
 
-    if (bytesReceived > 0) {
-        buffer[bytesReceived] = '\0';
-        request = buffer;
+        if (request.size() >= headersEnd + 4 + contentLength) {
+            break;
+        }
     }
 
     return request;
@@ -1398,9 +2788,15 @@ sendHttpResponse(SOCKET clientSocket, int statusCode, const std::string &content
         case 200:
             statusText = "OK";
             break;
+        case 400:
+            statusText = "Bad Request";
+            break;
         case 404:
             statusText = "Not Found";
             break;
+        case 409:
+            statusText = "Conflict";
+            break;
         case 500:
             statusText = "Internal Server Error";
             break;
@@ -1413,6 +2809,8 @@ sendHttpResponse(SOCKET clientSocket, int statusCode, const std::string &content
     response << "HTTP/1.1 " << statusCode << " " << statusText << "\r\n";
     response << "Content-Type: " << contentType << "\r\n";
     response << "Content-Length: " << responseBody.length() << "\r\n";
+    response << "X-MCPx64dbg-Schema: " << MCP_SCHEMA_VERSION << "\r\n";
+    response << "X-MCPx64dbg-Bits: " << sizeof(duint) * 8 << "\r\n";
     response << "Connection: close\r\n";
     response << "\r\n";
     response << responseBody;
@@ -1439,8 +2837,8 @@ std::unordered_map<std::string, std::string> parseQueryParams(const std::string
         size_t equalPos = pair.find('=');
 
         if (equalPos != std::string::npos) {
-            std::string key = pair.substr(0, equalPos);
-            std::string value = pair.substr(equalPos + 1);
+            std::string key = urlDecode(pair.substr(0, equalPos));
+            std::string value = urlDecode(pair.substr(equalPos + 1));
             params[key] = value;
         }
 
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// Registers lists the registers of a debugger, bits is 32 for x32dbg and 64 for x64dbg.
+func Registers(bits int) []RegisterEnum {
+	if bits == 32 {
+		return slices.Clone(registersX86)
+	}
+	return slices.Clone(registersX64)
+}
+
+// On tells whether the debugger of bits has r, x32dbg has neither RAX nor R8-R15.
+func (r RegisterEnum) On(bits int) bool {
+	if bits == 32 {
+		return slices.Contains(registersX86, r)
+	}
+	return r >= 0 && int(r) < len(registerSizes)
+}
+
+// Size is the width of r in bytes on the debugger of bits.
+func (r RegisterEnum) Size(bits int) int {
+	if r < 0 || int(r) >= len(registerSizes) {
+		return 0
+	}
+	if size := registerSizes[r]; size != 0 {
+		return size
+	}
+	return bits / 8
+}
+
+// checkRegister is the clear error for a register the debuggee has not, R8 under x32dbg.
+func checkRegister(reg RegisterEnum, bits int) error {
+	switch {
+	case reg.Size(bits) == 0:
+		return fmt.Errorf("x64dbg: %s: %w", reg, ErrUnknownRegister)
+	case !reg.On(bits):
+		return fmt.Errorf("x64dbg: %s is a register of x64dbg only, the debuggee is %d bit: %w", reg, bits, ErrUnknownRegister)
+	}
+	return nil
+}
+
+// checkKnown rejects a register the debuggee has not once the client knows the bitness, it never asks
+// for it, so Get stays one request.
+func (m RegisterManager) checkKnown(reg RegisterEnum) error {
+	if bits := m.client.shared().bits.Load(); bits != 0 {
+		return checkRegister(reg, int(bits))
+	}
+	return nil
+}
+
+// pointerRegister resolves name to a register as wide as a pointer of the debuggee.
+func (m RegisterManager) pointerRegister(ctx context.Context, name string) (RegisterEnum, error) {
+	reg, ok := RegisterEnumByName(name)
+	if !ok {
+		return 0, fmt.Errorf("x64dbg: %s: %w", name, ErrUnknownRegister)
+	}
+	bits, err := m.client.BitsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkRegister(reg, bits); err != nil {
+		return 0, err
+	}
+	if size := reg.Size(bits); size != bits/8 {
+		return 0, fmt.Errorf("x64dbg: %s is %d bit, not pointer sized on a %d bit debuggee, use the C alias or the full register", reg, size*8, bits)
+	}
+	return reg, nil
+}
+
+// Ptr reads a pointer sized register by name. The C aliases (CAX, CSP, CIP, CFLAGS ...) and DR0-DR7
+// work under both debuggers, RAX and R8-R15 only under x64dbg and EAX only under x32dbg.
+func (m RegisterManager) Ptr(name string) HexInt {
+	return must(m.TryPtr(name))
+}
+func (m RegisterManager) TryPtr(name string) (HexInt, error) {
+	return m.PtrContext(context.Background(), name)
+}
+func (m RegisterManager) PtrContext(ctx context.Context, name string) (HexInt, error) {
+	reg, err := m.pointerRegister(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	v, err := m.GetContext(ctx, reg)
+	return HexInt(v), err
+}
+func (m RegisterManager) SetPtr(name string, value uint) { mylog.Check(m.TrySetPtr(name, value)) }
+func (m RegisterManager) TrySetPtr(name string, value uint) error {
+	return m.SetPtrContext(context.Background(), name, value)
+}
+func (m RegisterManager) SetPtrContext(ctx context.Context, name string, value uint) error {
+	reg, err := m.pointerRegister(ctx, name)
+	if err != nil {
+		return err
+	}
+	_, err = m.SetContext(ctx, reg, value)
+	return err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestRegisterArch(t *testing.T) {
+	bits := "32"
+	answer := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(wireBitsHeader, bits)
+			w.Write([]byte(body))
+		}
+	}
+	p := &fakeServer{}
+	p.handle("/IsDebugActive", answer("true"))
+	p.handle("/Register/Get", answer("0x401000"))
+	p.handle("/Register/Set", answer("Register set successfully"))
+	c := p.client(t)
+	reg := c.X64dbg().Register
+
+	if err := c.Connect(t.Context()); err != nil || c.Bits() != 32 {
+		t.Fatalf("Connect: %v, %d bit", err, c.Bits())
+	}
+	if got := reg.Ptr("cip"); got != 0x401000 {
+		t.Errorf("Ptr(cip) = %#x", got)
+	}
+	reg.Ptr("EAX")
+	reg.SetPtr("CSP", 0x19ff00)
+	for _, name := range []string{"R8", "rax", "R15D", "SIL", "AX", "XMM0"} {
+		if _, err := reg.TryPtr(name); !errors.Is(err, ErrUnknownRegister) && name != "AX" || err == nil {
+			t.Errorf("Ptr(%s) on x32dbg: %v", name, err)
+		}
+	}
+	if _, err := reg.TryGet(R8); !errors.Is(err, ErrUnknownRegister) {
+		t.Errorf("Get(R8) on x32dbg: %v", err)
+	}
+	if _, err := reg.TryGetR8(); !errors.Is(err, ErrUnknownRegister) {
+		t.Errorf("TryGetR8 on x32dbg: %v", err)
+	}
+	if _, err := reg.TrySetR12D(1); !errors.Is(err, ErrUnknownRegister) {
+		t.Errorf("TrySetR12D on x32dbg: %v", err)
+	}
+	if _, err := c.X64dbg().Thread.TrySetRegister(4242, R12, 1); !errors.Is(err, ErrUnknownRegister) {
+		t.Errorf("thread SetRegister(R12) on x32dbg: %v", err)
+	}
+	want := []string{"/IsDebugActive ", "/Register/Get CIP", "/Register/Get EAX", "/Register/Set CSP"}
+	if requests := p.sent(func(r fakeRequest) string { return r.Path + " " + r.Query.Get("register") }); !slices.Equal(requests, want) {
+		t.Errorf("requests:\n%q\nwant\n%q", requests, want)
+	}
+
+	// 任何一次应答都会带上位数
+	bits = "64"
+	reg.Get(CIP)
+	if c.Bits() != 64 {
+		t.Errorf("Bits after x64dbg answered = %d", c.Bits())
+	}
+	if got := reg.Ptr("R8"); got != 0x401000 {
+		t.Errorf("Ptr(R8) = %#x", got)
+	}
+	if _, err := reg.TryPtr("EAX"); err == nil {
+		t.Error("Ptr(EAX) on x64dbg accepted")
+	}
+
+	if slices.Contains(Registers(32), R8) || !slices.Contains(Registers(64), R8) || !slices.Contains(Registers(32), CFLAGS) {
+		t.Error("Registers tables")
+	}
+	if CAX.Size(32) != 4 || CAX.Size(64) != 8 || R8D.Size(64) != 4 || AH.Size(32) != 1 || DR7.Size(64) != 8 || RegisterEnum(-1).Size(64) != 0 {
+		t.Error("Size")
+	}
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// BreakpointKind mirrors BPXTYPE of bridgemain.h, the values are bits so List can ask for several kinds at once.
+type BreakpointKind int
+
+const (
+	BreakpointNormal    BreakpointKind = 1
+	BreakpointHardware  BreakpointKind = 2
+	BreakpointMemory    BreakpointKind = 4
+	BreakpointDll       BreakpointKind = 8
+	BreakpointException BreakpointKind = 16
+
+	BreakpointAll = BreakpointNormal | BreakpointHardware | BreakpointMemory | BreakpointDll | BreakpointException
+)
+
+var breakpointKindNames = map[BreakpointKind]string{
+	BreakpointNormal:    "normal",
+	BreakpointHardware:  "hardware",
+	BreakpointMemory:    "memory",
+	BreakpointDll:       "dll",
+	BreakpointException: "exception",
+}
+
+func (k BreakpointKind) String() string {
+	if name, ok := breakpointKindNames[k]; ok {
+		return name
+	}
+	return "BreakpointKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// HardwareType mirrors BPHWTYPE, HardwareExecute only works with HardwareByte.
+type HardwareType uint8
+
+const (
+	HardwareAccess HardwareType = iota
+	HardwareWrite
+	HardwareExecute
+)
+
+var hardwareTypeNames = [...]string{"access", "write", "execute"}
+
+// HardwareSize mirrors BPHWSIZE.
+type HardwareSize uint8
+
+const (
+	HardwareByte HardwareSize = iota
+	HardwareWord
+	HardwareDword
+	HardwareQword
+)
+
+var hardwareSizeNames = [...]string{"byte", "word", "dword", "qword"}
+
+func (s HardwareSize) Bytes() int { return 1 << s }
+
+// MemoryType mirrors BPMEMTYPE.
+type MemoryType uint8
+
+const (
+	MemoryAccess MemoryType = iota
+	MemoryRead
+	MemoryWrite
+	MemoryExecute
+)
+
+var memoryTypeNames = [...]string{"access", "read", "write", "execute"}
+
+// DllType mirrors BPDLLTYPE.
+type DllType uint8
+
+const (
+	DllLoad DllType = iota + 1
+	DllUnload
+	DllAll
+)
+
+var dllTypeNames = [...]string{"", "load", "unload", "all"}
+
+// ExceptionChance mirrors BPEXTYPE.
+type ExceptionChance uint8
+
+const (
+	ExceptionFirstChance ExceptionChance = iota + 1
+	ExceptionSecondChance
+	ExceptionAllChances
+)
+
+var exceptionChanceNames = [...]string{"", "first", "second", "all"}
+
+func (t HardwareType) String() string { return enumName(hardwareTypeNames[:], t, "HardwareType") }
+func (s HardwareSize) String() string { return enumName(hardwareSizeNames[:], s, "HardwareSize") }
+func (t MemoryType) String() string   { return enumName(memoryTypeNames[:], t, "MemoryType") }
+func (t DllType) String() string      { return enumName(dllTypeNames[:], t, "DllType") }
+func (c ExceptionChance) String() string {
+	return enumName(exceptionChanceNames[:], c, "ExceptionChance")
+}
+
+// enumName 越界时和 BreakpointKind.String 一样给出 "HardwareType(7)"
+func enumName[T ~uint8](names []string, v T, typ string) string {
+	if int(v) < len(names) && names[v] != "" {
+		return names[v]
+	}
+	return typ + "(" + strconv.Itoa(int(v)) + ")"
+}
+
+// checkEnum rejects a value without a name before it ends up in a command.
+func checkEnum[T ~uint8](names []string, v T, typ string) error {
+	if int(v) < len(names) && names[v] != "" {
+		return nil
+	}
+	return fmt.Errorf("x64dbg: invalid %s", enumName(names, v, typ))
+}
+
+// TypeEx 的含义取决于 Kind，用下面这几个方法按类型取
+func (bp Breakpoint) HardwareType() HardwareType       { return HardwareType(bp.TypeEx) }
+func (bp Breakpoint) MemoryType() MemoryType           { return MemoryType(bp.TypeEx) }
+func (bp Breakpoint) DllType() DllType                 { return DllType(bp.TypeEx) }
+func (bp Breakpoint) ExceptionChance() ExceptionChance { return ExceptionChance(bp.TypeEx) }
+
+// target is the argument x64dbg's breakpoint commands identify bp by, dll breakpoints go by module name
+// and exception breakpoints by code.
+func (bp Breakpoint) target() string {
+	if bp.Kind == BreakpointDll {
+		return strconv.Quote(bp.Module)
+	}
+	return fmt.Sprintf("0x%x", uint(bp.Address))
+}
+
+// delete, enable, disable command per kind, see registercommands in mcp.go
+var breakpointCommands = map[BreakpointKind][3]string{
+	BreakpointNormal:    {"DeleteBPX", "EnableBPX", "DisableBPX"},
+	BreakpointHardware:  {"DeleteHardwareBreakpoint", "EnableHardwareBreakpoint", "DisableHardwareBreakpoint"},
+	BreakpointMemory:    {"DeleteMemoryBPX", "EnableMemoryBreakpoint", "DisableMemoryBreakpoint"},
+	BreakpointDll:       {"LibrarianRemoveBreakpoint", "LibrarianEnableBreakpoint", "LibrarianDisableBreakpoint"},
+	BreakpointException: {"DeleteExceptionBPX", "EnableExceptionBPX", "DisableExceptionBPX"},
+}
+
+func (b breakpoints) exec(ctx context.Context, cmd string) error {
+	_, err := command{b.client}.ExecContext(ctx, cmd)
+	return err
+}
+
+// set runs cmd and reads the new breakpoint back from the plugin so callers get the typed Breakpoint.
+func (b breakpoints) set(ctx context.Context, kind BreakpointKind, cmd string, match func(Breakpoint) bool) (Breakpoint, error) {
+	if err := b.exec(ctx, cmd); err != nil {
+		return Breakpoint{}, err
+	}
+	list, err := b.ListContext(ctx, kind)
+	if err != nil {
+		return Breakpoint{}, err
+	}
+	for _, bp := range list {
+		if match(bp) {
+			return bp, nil
+		}
+	}
+	return Breakpoint{}, fmt.Errorf("x64dbg: %s: breakpoint missing from list: %w", cmd, ErrNotFound)
+}
+
+func atAddress(address int) func(Breakpoint) bool {
+	return func(bp Breakpoint) bool { return int(bp.Address) == address }
+}
+
+func (b breakpoints) apply(ctx context.Context, bp Breakpoint, op int) error {
+	commands, ok := breakpointCommands[bp.Kind]
+	if !ok {
+		return fmt.Errorf("x64dbg: unknown breakpoint kind %v", bp.Kind)
+	}
+	return b.exec(ctx, commands[op]+" "+bp.target())
+}
+
+// List returns the breakpoints of the given kinds, 0 means all, like the bplist command.
+func (b breakpoints) List(kinds BreakpointKind) []Breakpoint {
+	return must(b.TryList(kinds))
+}
+func (b breakpoints) TryList(kinds BreakpointKind) ([]Breakpoint, error) {
+	return b.ListContext(context.Background(), kinds)
+}
+func (b breakpoints) ListContext(ctx context.Context, kinds BreakpointKind) ([]Breakpoint, error) {
+	if kinds == 0 {
+		kinds = BreakpointAll
+	}
+	return tryRequest[[]Breakpoint](b.client, ctx, "Breakpoint/List", map[string]string{"type": strconv.Itoa(int(kinds))})
+}
+func (b breakpoints) Set(address int) Breakpoint {
+	return must(b.TrySet(address))
+}
+func (b breakpoints) TrySet(address int) (Breakpoint, error) {
+	return b.SetContext(context.Background(), address)
+}
+func (b breakpoints) SetContext(ctx context.Context, address int) (Breakpoint, error) {
+	return b.set(ctx, BreakpointNormal, fmt.Sprintf("SetBPX 0x%x", address), atAddress(address))
+}
+func (b breakpoints) SetHardware(address int, typ HardwareType, size HardwareSize) Breakpoint {
+	return must(b.TrySetHardware(address, typ, size))
+}
+func (b breakpoints) TrySetHardware(address int, typ HardwareType, size HardwareSize) (Breakpoint, error) {
+	return b.SetHardwareContext(context.Background(), address, typ, size)
+}
+func (b breakpoints) SetHardwareContext(ctx context.Context, address int, typ HardwareType, size HardwareSize) (Breakpoint, error) {
+	if err := checkEnum(hardwareTypeNames[:], typ, "HardwareType"); err != nil {
+		return Breakpoint{}, err
+	}
+	if err := checkEnum(hardwareSizeNames[:], size, "HardwareSize"); err != nil {
+		return Breakpoint{}, err
+	}
+	if typ == HardwareExecute && size != HardwareByte {
+		return Breakpoint{}, fmt.Errorf("x64dbg: hardware execute breakpoints are HardwareByte, not %s", size)
+	}
+	cmd := fmt.Sprintf("SetHardwareBreakpoint 0x%x, %c, %d", address, "rwx"[typ], size.Bytes())
+	return b.set(ctx, BreakpointHardware, cmd, atAddress(address))
+}
+
+// SetMemory guards the whole page(s) of address, singleshot removes it after the first hit.
+func (b breakpoints) SetMemory(address int, typ MemoryType, singleshot bool) Breakpoint {
+	return must(b.TrySetMemory(address, typ, singleshot))
+}
+func (b breakpoints) TrySetMemory(address int, typ MemoryType, singleshot bool) (Breakpoint, error) {
+	return b.SetMemoryContext(context.Background(), address, typ, singleshot)
+}
+func (b breakpoints) SetMemoryContext(ctx context.Context, address int, typ MemoryType, singleshot bool) (Breakpoint, error) {
+	if err := checkEnum(memoryTypeNames[:], typ, "MemoryType"); err != nil {
+		return Breakpoint{}, err
+	}
+	restore := 1
+	if singleshot {
+		restore = 0
+	}
+	cmd := fmt.Sprintf("SetMemoryBPX 0x%x, %d, %c", address, restore, "arwx"[typ])
+	return b.set(ctx, BreakpointMemory, cmd, atAddress(address))
+}
+func (b breakpoints) SetMemoryRange(address int, size int, typ MemoryType) Breakpoint {
+	return must(b.TrySetMemoryRange(address, size, typ))
+}
+func (b breakpoints) TrySetMemoryRange(address int, size int, typ MemoryType) (Breakpoint, error) {
+	return b.SetMemoryRangeContext(context.Background(), address, size, typ)
+}
+func (b breakpoints) SetMemoryRangeContext(ctx context.Context, address int, size int, typ MemoryType) (Breakpoint, error) {
+	if err := checkEnum(memoryTypeNames[:], typ, "MemoryType"); err != nil {
+		return Breakpoint{}, err
+	}
+	if size <= 0 {
+		return Breakpoint{}, fmt.Errorf("x64dbg: memory breakpoint range of %d bytes", size)
+	}
+	cmd := fmt.Sprintf("SetMemoryRangeBPX 0x%x, 0x%x, %c", address, size, "arwx"[typ])
+	return b.set(ctx, BreakpointMemory, cmd, atAddress(address))
+}
+func (b breakpoints) SetDll(name string, typ DllType) Breakpoint {
+	return must(b.TrySetDll(name, typ))
+}
+func (b breakpoints) TrySetDll(name string, typ DllType) (Breakpoint, error) {
+	return b.SetDllContext(context.Background(), name, typ)
+}
+func (b breakpoints) SetDllContext(ctx context.Context, name string, typ DllType) (Breakpoint, error) {
+	if err := checkEnum(dllTypeNames[:], typ, "DllType"); err != nil {
+		return Breakpoint{}, err
+	}
+	cmd := fmt.Sprintf("LibrarianSetBreakpoint %s, %c", strconv.Quote(name), " lua"[typ])
+	return b.set(ctx, BreakpointDll, cmd, func(bp Breakpoint) bool { return strings.EqualFold(bp.Module, name) })
+}
+
+// SetException breaks on an exception code such as 0xC0000005 (EXCEPTION_ACCESS_VIOLATION).
+func (b breakpoints) SetException(code uint32, chance ExceptionChance) Breakpoint {
+	return must(b.TrySetException(code, chance))
+}
+func (b breakpoints) TrySetException(code uint32, chance ExceptionChance) (Breakpoint, error) {
+	return b.SetExceptionContext(context.Background(), code, chance)
+}
+func (b breakpoints) SetExceptionContext(ctx context.Context, code uint32, chance ExceptionChance) (Breakpoint, error) {
+	if err := checkEnum(exceptionChanceNames[:], chance, "ExceptionChance"); err != nil {
+		return Breakpoint{}, err
+	}
+	cmd := fmt.Sprintf("SetExceptionBPX 0x%x, %s", code, chance)
+	return b.set(ctx, BreakpointException, cmd, atAddress(int(code)))
+}
+func (b breakpoints) Delete(bp Breakpoint) { mylog.Check(b.TryDelete(bp)) }
+func (b breakpoints) TryDelete(bp Breakpoint) error {
+	return b.DeleteContext(context.Background(), bp)
+}
+func (b breakpoints) DeleteContext(ctx context.Context, bp Breakpoint) error {
+	return b.apply(ctx, bp, 0)
+}
+func (b breakpoints) Enable(bp Breakpoint) { mylog.Check(b.TryEnable(bp)) }
+func (b breakpoints) TryEnable(bp Breakpoint) error {
+	return b.EnableContext(context.Background(), bp)
+}
+func (b breakpoints) EnableContext(ctx context.Context, bp Breakpoint) error {
+	return b.apply(ctx, bp, 1)
+}
+func (b breakpoints) Disable(bp Breakpoint) { mylog.Check(b.TryDisable(bp)) }
+func (b breakpoints) TryDisable(bp Breakpoint) error {
+	return b.DisableContext(context.Background(), bp)
+}
+func (b breakpoints) DisableContext(ctx context.Context, bp Breakpoint) error {
+	return b.apply(ctx, bp, 2)
+}
+
+// BreakpointOptions are the settings every breakpoint kind shares, see the "Conditional Breakpoint Control"
+// commands of x64dbg. Empty strings clear the setting. x64dbg does not report LogFile back, so Options leaves
+// it empty and a failed SetOptions cannot restore it.
+type BreakpointOptions struct {
+	Name             string `json:"name"`
+	Condition        string `json:"condition"`
+	Log              string `json:"log"`
+	LogCondition     string `json:"logCondition"`
+	Command          string `json:"command"`
+	CommandCondition string `json:"commandCondition"`
+	LogFile          string `json:"logFile"`
+	FastResume       bool   `json:"fastResume"`
+	Singleshot       bool   `json:"singleshot"`
+	Silent           bool   `json:"silent"`
+}
+
+func (bp Breakpoint) Options() BreakpointOptions {
+	return BreakpointOptions{
+		Name:             bp.Name,
+		Condition:        bp.BreakCondition,
+		Log:              bp.LogText,
+		LogCondition:     bp.LogCondition,
+		Command:          bp.CommandText,
+		CommandCondition: bp.CommandCondition,
+		FastResume:       bp.FastResume,
+		Singleshot:       bp.Singleshot,
+		Silent:           bp.Silent,
+	}
+}
+
+// Set<x>Name, Set<x>Condition ... Reset<x>HitCount per kind, see registercommands in mcp.go
+var breakpointOptionCommands = map[BreakpointKind]string{
+	BreakpointNormal:    "Breakpoint",
+	BreakpointHardware:  "HardwareBreakpoint",
+	BreakpointMemory:    "MemoryBreakpoint",
+	BreakpointDll:       "LibrarianBreakpoint",
+	BreakpointException: "ExceptionBreakpoint",
+}
+
+// commands sets every option of bp to o, one x64dbg command each.
+func (o BreakpointOptions) commands(bp Breakpoint) ([]string, error) {
+	kind, ok := breakpointOptionCommands[bp.Kind]
+	if !ok {
+		return nil, fmt.Errorf("x64dbg: unknown breakpoint kind %v", bp.Kind)
+	}
+	var commands []string
+	text := func(option, value string) {
+		cmd := "Set" + kind + option + " " + bp.target()
+		if value != "" {
+			cmd += ", " + strconv.Quote(value)
+		}
+		commands = append(commands, cmd)
+	}
+	flag := func(option string, value bool) {
+		commands = append(commands, fmt.Sprintf("Set%s%s %s, %d", kind, option, bp.target(), boolToInt(value)))
+	}
+	text("Name", o.Name)
+	text("Condition", o.Condition)
+	text("Log", o.Log)
+	text("LogCondition", o.LogCondition)
+	text("Command", o.Command)
+	text("CommandCondition", o.CommandCondition)
+	if o.LogFile != "" {
+		text("LogFile", o.LogFile)
+	}
+	flag("FastResume", o.FastResume)
+	flag("Singleshoot", o.Singleshot)
+	flag("Silent", o.Silent)
+	return commands, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// same reports whether bp and other are the same breakpoint, the way x64dbg's commands look them up.
+func (bp Breakpoint) same(other Breakpoint) bool {
+	if bp.Kind != other.Kind {
+		return false
+	}
+	if bp.Kind == BreakpointDll {
+		return strings.EqualFold(bp.Module, other.Module)
+	}
+	return bp.Address == other.Address
+}
+
+// Get reads bp back from the plugin, with its current state, options and hit count.
+func (b breakpoints) Get(bp Breakpoint) Breakpoint {
+	return must(b.TryGet(bp))
+}
+func (b breakpoints) TryGet(bp Breakpoint) (Breakpoint, error) {
+	return b.GetContext(context.Background(), bp)
+}
+func (b breakpoints) GetContext(ctx context.Context, bp Breakpoint) (Breakpoint, error) {
+	list, err := b.ListContext(ctx, bp.Kind)
+	if err != nil {
+		return Breakpoint{}, err
+	}
+	for _, current := range list {
+		if current.same(bp) {
+			return current, nil
+		}
+	}
+	return Breakpoint{}, fmt.Errorf("x64dbg: %v breakpoint %s: %w", bp.Kind, bp.target(), ErrNotFound)
+}
+
+// SetOptions replaces all options of bp and returns it read back. The plugin runs the commands in one
+// request and restores the previous options when one of them fails, so bp is never left half configured.
+func (b breakpoints) SetOptions(bp Breakpoint, options BreakpointOptions) Breakpoint {
+	return must(b.TrySetOptions(bp, options))
+}
+func (b breakpoints) TrySetOptions(bp Breakpoint, options BreakpointOptions) (Breakpoint, error) {
+	return b.SetOptionsContext(context.Background(), bp, options)
+}
+func (b breakpoints) SetOptionsContext(ctx context.Context, bp Breakpoint, options BreakpointOptions) (Breakpoint, error) {
+	current, err := b.GetContext(ctx, bp)
+	if err != nil {
+		return Breakpoint{}, err
+	}
+	apply, err := options.commands(current)
+	if err != nil {
+		return Breakpoint{}, err
+	}
+	rollback, err := current.Options().commands(current)
+	if err != nil {
+		return Breakpoint{}, err
+	}
+	payload := strings.Join(apply, "\n") + "\n\n" + strings.Join(rollback, "\n")
+	if _, err := tryPost[string](b.client, ctx, "Breakpoint/SetOptions", nil, payload); err != nil {
+		return Breakpoint{}, err
+	}
+	return b.GetContext(ctx, current)
+}
+
+// ResetHitCount sets the hit count of bp back to 0.
+func (b breakpoints) ResetHitCount(bp Breakpoint) { mylog.Check(b.TryResetHitCount(bp)) }
+func (b breakpoints) TryResetHitCount(bp Breakpoint) error {
+	return b.ResetHitCountContext(context.Background(), bp)
+}
+func (b breakpoints) ResetHitCountContext(ctx context.Context, bp Breakpoint) error {
+	kind, ok := breakpointOptionCommands[bp.Kind]
+	if !ok {
+		return fmt.Errorf("x64dbg: unknown breakpoint kind %v", bp.Kind)
+	}
+	return b.exec(ctx, "Reset"+kind+"HitCount "+bp.target())
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeBreakpointPlugin keeps a breakpoint table that the x64dbg breakpoint commands edit
+// and Breakpoint/List reports, like DbgGetBpList in the plugin.
+type fakeBreakpointPlugin struct {
+	fakeServer
+	bps []Breakpoint
+}
+
+func (p *fakeBreakpointPlugin) exec(cmd string) bool {
+	name, args, _ := strings.Cut(cmd, " ")
+	argv := strings.Split(args, ", ")
+	num := func(i int) HexInt {
+		v, _ := strconv.ParseUint(strings.TrimPrefix(argv[i], "0x"), 16, 64)
+		return HexInt(v)
+	}
+	find := func(kind BreakpointKind) int {
+		for i, bp := range p.bps {
+			if bp.Kind == kind && (kind == BreakpointDll && bp.Module == strings.Trim(argv[0], `"`) || kind != BreakpointDll && bp.Address == num(0)) {
+				return i
+			}
+		}
+		return -1
+	}
+	switch name {
+	case "SetBPX":
+		p.bps = append(p.bps, Breakpoint{Kind: BreakpointNormal, Address: num(0), Enabled: true})
+	case "SetHardwareBreakpoint":
+		size, _ := strconv.Atoi(argv[2])
+		hw := Breakpoint{Kind: BreakpointHardware, Address: num(0), Enabled: true, TypeEx: uint8(strings.Index("rwx", argv[1]))}
+		for size > 1 {
+			hw.HardwareSize++
+			size >>= 1
+		}
+		p.bps = append(p.bps, hw)
+	case "SetMemoryBPX":
+		p.bps = append(p.bps, Breakpoint{Kind: BreakpointMemory, Address: num(0), Enabled: true, Singleshot: argv[1] == "0", TypeEx: uint8(strings.Index("arwx", argv[2]))})
+	case "SetMemoryRangeBPX":
+		p.bps = append(p.bps, Breakpoint{Kind: BreakpointMemory, Address: num(0), Enabled: true, TypeEx: uint8(strings.Index("arwx", argv[2]))})
+	case "LibrarianSetBreakpoint":
+		p.bps = append(p.bps, Breakpoint{Kind: BreakpointDll, Module: strings.Trim(argv[0], `"`), Enabled: true, TypeEx: uint8(strings.Index(" lua", argv[1]))})
+	case "SetExceptionBPX":
+		chance := map[string]uint8{"first": 1, "second": 2, "all": 3}[argv[1]]
+		p.bps = append(p.bps, Breakpoint{Kind: BreakpointException, Address: num(0), Enabled: true, TypeEx: chance})
+	default:
+		if p.option(name, args) {
+			return true
+		}
+		for kind, commands := range breakpointCommands {
+			for op, c := range commands {
+				if c != name {
+					continue
+				}
+				i := find(kind)
+				if i < 0 {
+					return false
+				}
+				switch op {
+				case 0:
+					p.bps = append(p.bps[:i], p.bps[i+1:]...)
+				default:
+					p.bps[i].Enabled = op == 1
+				}
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// option handles Set<kind><option> and Reset<kind>HitCount, a condition containing "invalid" fails to parse.
+func (p *fakeBreakpointPlugin) option(name, args string) bool {
+	for kind, prefix := range breakpointOptionCommands {
+		option, ok := strings.CutPrefix(name, "Set"+prefix)
+		if name == "Reset"+prefix+"HitCount" {
+			option, ok = "HitCount", true
+		}
+		if !ok {
+			continue
+		}
+		target, value, _ := strings.Cut(args, ", ")
+		i := -1
+		for j, bp := range p.bps {
+			if bp.Kind == kind && (kind == BreakpointDll && strconv.Quote(bp.Module) == target || kind != BreakpointDll && bp.target() == target) {
+				i = j
+			}
+		}
+		if i < 0 {
+			return false
+		}
+		if text, err := strconv.Unquote(value); err == nil {
+			value = text
+		}
+		bp := &p.bps[i]
+		switch option {
+		case "Name":
+			bp.Name = value
+		case "Condition":
+			if strings.Contains(value, "invalid") {
+				return false
+			}
+			bp.BreakCondition = value
+		case "Log":
+			bp.LogText = value
+		case "LogCondition":
+			bp.LogCondition = value
+		case "Command":
+			bp.CommandText = value
+		case "CommandCondition":
+			bp.CommandCondition = value
+		case "LogFile":
+		case "FastResume":
+			bp.FastResume = value == "1"
+		case "Singleshoot":
+			bp.Singleshot = value == "1"
+		case "Silent":
+			bp.Silent = value == "1"
+		case "HitCount":
+			bp.HitCount = 0
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func (p *fakeBreakpointPlugin) client(t *testing.T) x64dbg {
+	t.Helper()
+	p.handle("/ExecCommand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !p.exec(string(body)) {
+			http.Error(w, "Command execution failed", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("Command executed successfully (no output captured)"))
+	})
+	p.handle("/Breakpoint/SetOptions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		apply, rollback, _ := strings.Cut(string(body), "\n\n")
+		for _, cmd := range strings.Split(apply, "\n") {
+			if !p.exec(cmd) {
+				for _, cmd := range strings.Split(rollback, "\n") {
+					p.exec(cmd)
+				}
+				http.Error(w, "Command failed, options rolled back: "+cmd, http.StatusInternalServerError)
+				return
+			}
+		}
+		w.Write([]byte("Breakpoint options set"))
+	})
+	p.handle("/Breakpoint/List", func(w http.ResponseWriter, r *http.Request) {
+		kinds, _ := strconv.Atoi(r.URL.Query().Get("type"))
+		list := []Breakpoint{}
+		for _, bp := range p.bps {
+			if int(bp.Kind)&kinds != 0 {
+				list = append(list, bp)
+			}
+		}
+		json.NewEncoder(w).Encode(list)
+	})
+	return p.fakeServer.client(t).X64dbg()
+}
+
+func TestBreakpoints(t *testing.T) {
+	var p fakeBreakpointPlugin
+	b := p.client(t).Breakpoints
+
+	hw := b.SetHardware(0x401000, HardwareWrite, HardwareDword)
+	if hw.Kind != BreakpointHardware || hw.Address != 0x401000 || hw.HardwareType() != HardwareWrite || hw.HardwareSize.Bytes() != 4 {
+		t.Errorf("SetHardware: %+v", hw)
+	}
+	mem := b.SetMemory(0x402000, MemoryExecute, true)
+	if mem.MemoryType() != MemoryExecute || !mem.Singleshot {
+		t.Errorf("SetMemory: %+v", mem)
+	}
+	if r := b.SetMemoryRange(0x403000, 0x20, MemoryWrite); r.MemoryType() != MemoryWrite {
+		t.Errorf("SetMemoryRange: %+v", r)
+	}
+	dll := b.SetDll("kernel32.dll", DllLoad)
+	if dll.Kind != BreakpointDll || dll.DllType() != DllLoad {
+		t.Errorf("SetDll: %+v", dll)
+	}
+	ex := b.SetException(0xC0000005, ExceptionFirstChance)
+	if ex.Address != 0xC0000005 || ex.ExceptionChance() != ExceptionFirstChance {
+		t.Errorf("SetException: %+v", ex)
+	}
+	b.Set(0x401010)
+
+	want := []string{
+		"SetHardwareBreakpoint 0x401000, w, 4",
+		"SetMemoryBPX 0x402000, 0, x",
+		"SetMemoryRangeBPX 0x403000, 0x20, w",
+		`LibrarianSetBreakpoint "kernel32.dll", l`,
+		"SetExceptionBPX 0xc0000005, first",
+		"SetBPX 0x401010",
+	}
+	if commands := p.bodies("/ExecCommand"); strings.Join(commands, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	// 非法参数在拼命令之前就报错，不发请求也不 panic
+	for name, try := range map[string]func() (Breakpoint, error){
+		"hardware type":  func() (Breakpoint, error) { return b.TrySetHardware(0x401000, 3, HardwareByte) },
+		"hardware size":  func() (Breakpoint, error) { return b.TrySetHardware(0x401000, HardwareWrite, 4) },
+		"execute dword":  func() (Breakpoint, error) { return b.TrySetHardware(0x401000, HardwareExecute, HardwareDword) },
+		"memory type":    func() (Breakpoint, error) { return b.TrySetMemory(0x402000, 4, false) },
+		"memory range":   func() (Breakpoint, error) { return b.TrySetMemoryRange(0x403000, 0, MemoryRead) },
+		"dll type":       func() (Breakpoint, error) { return b.TrySetDll("kernel32.dll", 0) },
+		"exception zero": func() (Breakpoint, error) { return b.TrySetException(0xC0000005, 0) },
+	} {
+		if _, err := try(); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+	if commands := p.bodies("/ExecCommand"); len(commands) != len(want) {
+		t.Errorf("invalid arguments sent commands: %q", commands[len(want):])
+	}
+	if got := (Breakpoint{Kind: BreakpointMemory, TypeEx: 3}).HardwareType().String(); got != "HardwareType(3)" {
+		t.Errorf("HardwareType(3).String() = %q", got)
+	}
+	if got := DllType(0).String(); got != "DllType(0)" {
+		t.Errorf("DllType(0).String() = %q", got)
+	}
+
+	if got := len(b.List(0)); got != 6 {
+		t.Errorf("List(0) = %d breakpoints", got)
+	}
+	if got := b.List(BreakpointMemory | BreakpointDll); len(got) != 3 {
+		t.Errorf("List(memory|dll) = %+v", got)
+	}
+
+	b.Disable(dll)
+	b.Disable(hw)
+	for _, bp := range b.List(BreakpointHardware | BreakpointDll) {
+		if bp.Enabled {
+			t.Errorf("still enabled after Disable: %+v", bp)
+		}
+	}
+	b.Enable(hw)
+	if !b.List(BreakpointHardware)[0].Enabled {
+		t.Error("hardware breakpoint not enabled")
+	}
+
+	for _, bp := range b.List(0) {
+		b.Delete(bp)
+	}
+	if got := b.List(0); len(got) != 0 {
+		t.Errorf("left after Delete: %+v", got)
+	}
+	if err := b.TryDelete(hw); err == nil {
+		t.Error("deleting a missing breakpoint succeeded")
+	}
+}
+
+func TestBreakpointOptions(t *testing.T) {
+	var p fakeBreakpointPlugin
+	b := p.client(t).Breakpoints
+
+	for _, bp := range []Breakpoint{
+		b.Set(0x401000),
+		b.SetHardware(0x402000, HardwareExecute, HardwareByte),
+		b.SetMemory(0x403000, MemoryWrite, false),
+		b.SetDll("user32.dll", DllAll),
+		b.SetException(0xC0000005, ExceptionAllChances),
+	} {
+		options := BreakpointOptions{
+			Name:             "entry",
+			Condition:        "rcx==0",
+			Log:              "rcx={rcx}, \"quoted\"",
+			LogCondition:     "rdx!=0",
+			Command:          "StepOut",
+			CommandCondition: "1",
+			LogFile:          `C:\bp.log`,
+			FastResume:       true,
+			Silent:           true,
+		}
+		got := b.SetOptions(bp, options)
+		options.LogFile = ""
+		if got.Options() != options {
+			t.Errorf("%v: options read back\n got %+v\nwant %+v", bp.Kind, got.Options(), options)
+		}
+
+		for i := range p.bps {
+			if p.bps[i].same(bp) {
+				p.bps[i].HitCount = 5
+			}
+		}
+		if got := b.Get(bp).HitCount; got != 5 {
+			t.Errorf("%v: HitCount = %d", bp.Kind, got)
+		}
+		b.ResetHitCount(bp)
+		if got := b.Get(bp).HitCount; got != 0 {
+			t.Errorf("%v: HitCount after reset = %d", bp.Kind, got)
+		}
+
+		if _, err := b.TrySetOptions(bp, BreakpointOptions{Name: "changed", Condition: "invalid("}); err == nil {
+			t.Errorf("%v: invalid condition accepted", bp.Kind)
+		}
+		if got := b.Get(bp).Options(); got != options {
+			t.Errorf("%v: not rolled back\n got %+v\nwant %+v", bp.Kind, got, options)
+		}
+	}
+
+	want := []string{
+		`SetLibrarianBreakpointName "user32.dll", "entry"`,
+		`SetLibrarianBreakpointLog "user32.dll", "rcx={rcx}, \"quoted\""`,
+		`SetLibrarianBreakpointLogFile "user32.dll", "C:\\bp.log"`,
+		`SetLibrarianBreakpointSingleshoot "user32.dll", 0`,
+		`ResetLibrarianBreakpointHitCount "user32.dll"`,
+		`SetExceptionBreakpointCondition 0xc0000005, "rcx==0"`,
+	}
+	// 选项命令一行一条放在 SetOptions 的请求体里
+	commands := strings.Split(strings.Join(p.bodies("/Breakpoint/SetOptions"), "\n"), "\n")
+	commands = append(commands, p.bodies("/ExecCommand")...)
+	for _, cmd := range want {
+		if !slices.Contains(commands, cmd) {
+			t.Errorf("command %s not sent", cmd)
+		}
+	}
+
+	if _, err := b.TryGet(Breakpoint{Kind: BreakpointNormal, Address: 0x999}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing breakpoint: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultX64dbgServer = "http://127.0.0.1:8888/"
+
+// Client is one MCPx64dbg plugin instance, use one Client per debugger to drive x32dbg and x64dbg side by side.
+// The zero value of every facade type falls back to DefaultClient.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Retry      RetryPolicy
+	Logger     *log.Logger // nil 不输出日志，stdio 模式下 stdout 被 mcp 占用
+
+	ctx     context.Context // WithContext 绑定的
+	parent  *Client         // WithContext 的副本和原来的 client 共用下面的状态
+	symbols symbolCache
+	bits    atomic.Int32 // 32 或 64，0 是还不知道
+}
+
+// RetryPolicy only retries requests that never reached the plugin (connection refused while x64dbg is busy or restarting),
+// a request the plugin has seen is never sent twice because Run/Step/Write are not idempotent.
+type RetryPolicy struct {
+	MaxAttempts int           // <= 1 不重试
+	Backoff     time.Duration // 每次重试前等待，之后翻倍
+}
+
+var DefaultClient = NewClient(DefaultX64dbgServer)
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/") + "/",
+		HTTPClient: &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: &http.Transport{
+				DisableKeepAlives: true,
+			},
+		},
+		Retry: RetryPolicy{MaxAttempts: 3, Backoff: 200 * time.Millisecond},
+	}
+}
+
+// WithContext returns a client whose requests use ctx where their own context can never be cancelled,
+// which is the case for the methods without a ctx argument: Get stops when ctx is done like GetContext
+// does. The copy shares the symbol cache and bitness with c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c = c.orDefault()
+	return &Client{
+		BaseURL:    c.BaseURL,
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Logger:     c.Logger,
+		ctx:        ctx,
+		parent:     c.shared(),
+	}
+}
+
+// shared is the client that owns the symbol cache and bitness, the original one of a WithContext copy.
+func (c *Client) shared() *Client {
+	c = c.orDefault()
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
+// X64dbg returns the facade bound to this client.
+func (c *Client) X64dbg() x64dbg {
+	return x64dbg{
+		Command:      command{c},
+		Register:     register{RegisterManager{c}},
+		Memory:       memory{c},
+		Debug:        debug{c},
+		Assembler:    assembler{c},
+		Stack:        stack{c},
+		Flag:         flag{c},
+		Pattern:      pattern{c},
+		Misc:         misc{c},
+		Module:       module{c},
+		Disassembler: disassembler{c},
+		Breakpoints:  breakpoints{c},
+		Thread:       thread{c},
+		Xref:         xref{c},
+		Symbol:       symbol{c},
+	}
+}
+
+func (c *Client) orDefault() *Client {
+	if c == nil {
+		return DefaultClient
+	}
+	return c
+}
+
+// Connect checks that the plugin answers and learns the bitness of the debugger it runs in, see Bits.
+// Call it again after switching between x32dbg and x64dbg behind the same address or a restart of the
+// debuggee, it forgets the cached symbols as well.
+func (c *Client) Connect(ctx context.Context) error {
+	c = c.orDefault()
+	c.shared().bits.Store(0)
+	c.shared().symbols.drop("")
+	debugging, err := tryRequest[bool](c, ctx, "IsDebugActive", nil)
+	if err != nil || c.shared().bits.Load() != 0 || !debugging {
+		return err
+	}
+	_, err = c.BitsContext(ctx)
+	return err
+}
+
+// Bits is 32 when the plugin runs in x32dbg and 64 in x64dbg. The plugin names it in every response
+// and the client remembers the last one, plugins too old for that are probed with a read of RIP, which
+// only x64dbg knows, and need a debuggee for it.
+func (c *Client) Bits() int {
+	return must(c.TryBits())
+}
+func (c *Client) TryBits() (int, error) {
+	return c.BitsContext(context.Background())
+}
+func (c *Client) BitsContext(ctx context.Context) (int, error) {
+	c = c.orDefault()
+	bits := &c.shared().bits
+	if v := bits.Load(); v != 0 {
+		return int(v), nil
+	}
+	_, err := tryRequest[uint](c, ctx, "Register/Get", map[string]string{"register": RIP.String()})
+	var status *HTTPStatusError
+	switch {
+	case bits.Load() != 0:
+	case err == nil:
+		bits.Store(64)
+	case errors.As(err, &status) && status.Body == "Unknown register":
+		bits.Store(32)
+	default:
+		return 0, err
+	}
+	return int(bits.Load()), nil
+}
+
+// noteBits remembers the bitness a response names.
+func (c *Client) noteBits(h http.Header) {
+	switch h.Get(wireBitsHeader) {
+	case "32":
+		c.shared().bits.Store(32)
+	case "64":
+		c.shared().bits.Store(64)
+	}
+}
+
+func (c *Client) logf(format string, v ...any) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+func (c *Client) do(ctx context.Context, method, url, payload string) (*http.Response, error) {
+	return c.send(ctx, c.httpClient(), method, url, payload)
+}
+
+// stream is do without the client's Timeout, for responses that stay open until ctx is done.
+func (c *Client) stream(ctx context.Context, url string) (*http.Response, error) {
+	httpClient := *c.httpClient()
+	httpClient.Timeout = 0
+	return c.send(ctx, &httpClient, http.MethodGet, url, "")
+}
+
+func (c *Client) send(ctx context.Context, httpClient *http.Client, method, url, payload string) (*http.Response, error) {
+	if c.ctx != nil && ctx.Done() == nil {
+		ctx = c.ctx
+	}
+	backoff := c.Retry.Backoff
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if payload != "" {
+			body = strings.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		if payload != "" {
+			req.Header.Set("Content-Type", "text/plain")
+		}
+		c.logf("%s %s", method, url)
+		resp, err := httpClient.Do(req)
+		if err == nil || attempt >= c.Retry.MaxAttempts || !isDialError(err) {
+			return resp, err
+		}
+		c.logf("attempt %d: %v, retry in %v", attempt, err, backoff)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientSideBySide(t *testing.T) {
+	x32 := fakePlugin(t, map[string]string{"/Register/Get": "0x401000"}).X64dbg()
+	x64 := fakePlugin(t, map[string]string{"/Register/Get": "0x140001000"}).X64dbg()
+	if got := x32.Register.Get(CIP); got != 0x401000 {
+		t.Errorf("x32dbg CIP = %#x", got)
+	}
+	if got := x64.Register.Get(CIP); got != 0x140001000 {
+		t.Errorf("x64dbg CIP = %#x", got)
+	}
+}
+
+func TestClientDefault(t *testing.T) {
+	c := fakePlugin(t, map[string]string{"/Is_Debugging": "true"})
+	old := DefaultClient
+	DefaultClient = c
+	defer func() { DefaultClient = old }()
+
+	var x x64dbg
+	if !x.Debug.Debugging() {
+		t.Error("zero facade did not use DefaultClient")
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	// 拿一个刚释放的端口，连接会被拒绝
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	var logs bytes.Buffer
+	c := NewClient("http://" + addr)
+	c.Retry = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	c.Logger = log.New(&logs, "", 0)
+	if _, err := c.X64dbg().Debug.TryDebugging(); err == nil {
+		t.Fatal("expected dial error")
+	}
+	if got := strings.Count(logs.String(), "GET "); got != 3 {
+		t.Errorf("%d attempts, want 3:\n%s", got, logs.String())
+	}
+}
+
+func TestClientContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	x := NewClient(srv.URL).X64dbg()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := x.Memory.ReadContext(ctx, 0x401000, 16); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Memory.ReadContext: %v, want deadline exceeded", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := x.Debug.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Debug.RunContext: %v, want canceled", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotDebugging    = errors.New("x64dbg: not debugging")
+	ErrInvalidAddress  = errors.New("x64dbg: invalid address")
+	ErrNotFound        = errors.New("x64dbg: not found")
+	ErrUnknownEndpoint = errors.New("x64dbg: endpoint not implemented by plugin")
+	ErrSchemaVersion   = errors.New("x64dbg: plugin wire schema version mismatch")
+	ErrUnknownRegister = errors.New("x64dbg: unknown register")
+)
+
+// HTTPStatusError is returned when the plugin answers with a non 200 status.
+// errors.Is also matches the sentinel errors above, classified from the plugin's body text.
+type HTTPStatusError struct {
+	Endpoint   string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("x64dbg: %s: status %d: %s", e.Endpoint, e.StatusCode, e.Body)
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	body := strings.ToLower(e.Body)
+	switch {
+	case strings.Contains(body, "not debugging"):
+		return ErrNotDebugging
+	case strings.Contains(body, "invalid address"),
+		strings.Contains(body, "failed to read memory"),
+		strings.Contains(body, "failed to write memory"):
+		return ErrInvalidAddress
+	case body == "unknown register":
+		return ErrUnknownRegister
+	case body == "not found":
+		return ErrUnknownEndpoint
+	case e.StatusCode == 404:
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DecodeError is returned when a 200 response can not be decoded into the requested type.
+type DecodeError struct {
+	Endpoint string
+	Type     string
+	Body     string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("x64dbg: %s: decode %q as %s: %v", e.Endpoint, e.Body, e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTryErrors(t *testing.T) {
+	type reply struct {
+		status int
+		body   string
+	}
+	routes := map[string]reply{
+		"/Memory/Read":          {http.StatusBadRequest, "Invalid address or size format"},
+		"/Memory/Write":         {http.StatusInternalServerError, "Failed to write memory"},
+		"/Debug/Run":            {http.StatusConflict, "Not debugging"},
+		"/Pattern/FindMem":      {http.StatusNotFound, "Pattern not found"},
+		"/Misc/ParseExpression": {http.StatusOK, "not a number"},
+		"/Stack/Peek":           {http.StatusOK, "0x1234"},
+		"/Register/Get":         {http.StatusBadRequest, "Unknown register"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep, ok := routes[r.URL.Path]
+		if !ok {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(rep.status)
+		w.Write([]byte(rep.body))
+	}))
+	defer srv.Close()
+
+	x := NewClient(srv.URL).X64dbg()
+	_, err := x.Memory.TryRead(0x401000, 16)
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Memory.TryRead: %v, want ErrInvalidAddress", err)
+	}
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest || statusErr.Body != "Invalid address or size format" {
+		t.Errorf("Memory.TryRead: %#v", statusErr)
+	}
+	if _, err := x.Memory.TryWrite(0x401000, HexBytes{0x90}); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Memory.TryWrite: %v, want ErrInvalidAddress", err)
+	}
+	if err := x.Debug.TryRun(); !errors.Is(err, ErrNotDebugging) {
+		t.Errorf("Debug.TryRun: %v, want ErrNotDebugging", err)
+	}
+	if _, err := x.Pattern.TryFindMemory(0x401000, 0x1000, "488B"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Pattern.TryFindMemory: %v, want ErrNotFound", err)
+	}
+	if _, err := x.Module.TryGetList(); !errors.Is(err, ErrUnknownEndpoint) {
+		t.Errorf("Module.TryGetList: %v, want ErrUnknownEndpoint", err)
+	}
+	var decodeErr *DecodeError
+	if _, err := x.Misc.TryParseExpression("rip"); !errors.As(err, &decodeErr) || decodeErr.Type != "uint" {
+		t.Errorf("Misc.TryParseExpression: %v, want *DecodeError", err)
+	}
+	if _, err := x.Disassembler.TryAtAddressWithSize(0x401000, 0); err == nil {
+		t.Error("Disassembler.TryAtAddressWithSize: count 0 accepted")
+	}
+	if _, err := x.Register.TryGet(R8); !errors.Is(err, ErrUnknownRegister) {
+		t.Errorf("Register.TryGet: %v, want ErrUnknownRegister", err)
+	}
+	if v, err := x.Stack.TryPeek(0); err != nil || v != 0x1234 {
+		t.Errorf("Stack.TryPeek = %#x, %v", v, err)
+	}
+}
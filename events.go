@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DebugEventKind is the "type" of a DebugEvent, one per plugin callback cbDebugEvent registers.
+type DebugEventKind string
+
+const (
+	EventBreakpoint   DebugEventKind = "breakpoint"
+	EventException    DebugEventKind = "exception"
+	EventLoadDll      DebugEventKind = "loadDll"
+	EventUnloadDll    DebugEventKind = "unloadDll"
+	EventCreateThread DebugEventKind = "createThread"
+	EventExitThread   DebugEventKind = "exitThread"
+	EventExitProcess  DebugEventKind = "exitProcess"
+	EventPaused       DebugEventKind = "paused"
+	EventResumed      DebugEventKind = "resumed"
+	EventStepped      DebugEventKind = "stepped"
+)
+
+// Events subscribes to the plugin's /Events stream. The channel is closed when ctx is done or the
+// plugin drops the connection (x64dbg closed, http server toggled), subscribe again to continue.
+// Events are buffered, not dropped, but the plugin disconnects a receiver that falls 256 events
+// behind rather than stall x64dbg, the channel is closed then as well.
+func (d debug) Events(ctx context.Context) <-chan DebugEvent {
+	return must(d.TryEvents(ctx))
+}
+
+// TryEvents is Events, with the error of the subscription itself instead of a panic.
+func (d debug) TryEvents(ctx context.Context) (<-chan DebugEvent, error) {
+	const endpoint = "Events"
+	c := d.client.orDefault()
+	resp, err := c.stream(ctx, c.BaseURL+endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("x64dbg: %s: %w", endpoint, err)
+	}
+	if v := resp.Header.Get(wireSchemaHeader); v != "" && v != strconv.Itoa(WireSchemaVersion) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("x64dbg: %s: plugin schema %s, client schema %d: %w", endpoint, v, WireSchemaVersion, ErrSchemaVersion)
+	}
+	c.noteBits(resp.Header)
+	if resp.StatusCode != http.StatusOK {
+		var body strings.Builder
+		bufio.NewReader(resp.Body).WriteTo(&body)
+		resp.Body.Close()
+		return nil, &HTTPStatusError{Endpoint: endpoint, StatusCode: resp.StatusCode, Body: strings.TrimSpace(body.String())}
+	}
+
+	events := make(chan DebugEvent, 64)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		readEvents(c, resp, func(e DebugEvent) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return events, nil
+}
+
+// readEvents parses the text/event-stream body, only the data field is used. A malformed event is
+// logged and skipped, the stream goes on.
+func readEvents(c *Client, resp *http.Response, emit func(DebugEvent) bool) {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				data = append(data, strings.TrimPrefix(value, " "))
+			}
+			continue
+		}
+		if len(data) == 0 {
+			continue
+		}
+		var e DebugEvent
+		err := json.Unmarshal([]byte(strings.Join(data, "\n")), &e)
+		data = data[:0]
+		if err != nil {
+			c.logf("Events: %v", err)
+			continue
+		}
+		c.shared().symbols.follow(e)
+		if !emit(e) {
+			return
+		}
+	}
+}
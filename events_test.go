@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeEmitter stands in for cbDebugEvent: every string sent on emit goes out as one Server-Sent Event.
+func fakeEmitter(t *testing.T, schema string) (x64dbg, chan<- string) {
+	t.Helper()
+	emit := make(chan string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Events" {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set(wireSchemaHeader, schema)
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case data, ok := <-emit:
+				if !ok {
+					return
+				}
+				fmt.Fprint(w, data)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL).X64dbg(), emit
+}
+
+func nextEvent(t *testing.T, events <-chan DebugEvent) (DebugEvent, bool) {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		return e, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return DebugEvent{}, false
+}
+
+func TestEvents(t *testing.T) {
+	x, emit := fakeEmitter(t, strconv.Itoa(WireSchemaVersion))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := x.Debug.Events(ctx)
+
+	recorded, err := os.ReadFile(filepath.Join("testdata", "plugin", "Events.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		emit <- string(recorded)
+		emit <- ": comment lines and unknown fields are ignored\nid: 1\ndata: {\"type\":\"paused\",\ndata: \"addr\":\"0x401000\"}\n\n"
+		emit <- "data: {not json}\n\n"
+		emit <- "data: {\"type\":\"resumed\"}\n\n"
+	}()
+
+	want := []DebugEvent{
+		{Kind: EventBreakpoint, Address: 0x7ff6a1b21000, ThreadID: 4242, Breakpoint: &Breakpoint{Kind: BreakpointNormal, Address: 0x7ff6a1b21000, Enabled: true, Active: true, Name: "entry", Module: "a.exe", HitCount: 1}},
+		{Kind: EventPaused, Address: 0x7ff6a1b21000, ThreadID: 4242},
+		{Kind: EventResumed},
+		{Kind: EventException, Address: 0x7ff6a1b21005, ThreadID: 4242, Code: 0xc0000005, FirstChance: true},
+		{Kind: EventLoadDll, Address: 0x7ffd3e0a0000, Module: "kernel32.dll"},
+		{Kind: EventUnloadDll, Address: 0x7ffd3e0a0000, Module: "kernel32.dll"},
+		{Kind: EventCreateThread, Address: 0x7ff6a1b21100, ThreadID: 5150},
+		{Kind: EventExitThread, ThreadID: 5150},
+		{Kind: EventStepped, Address: 0x7ff6a1b21007, ThreadID: 4242},
+		{Kind: EventExitProcess, Code: 1},
+		{Kind: EventPaused, Address: 0x401000},
+		{Kind: EventResumed},
+	}
+	for i, w := range want {
+		got, ok := nextEvent(t, events)
+		if !ok {
+			t.Fatalf("event %d: channel closed", i)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("event %d:\n got %+v\nwant %+v", i, got, w)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestEventsClosed(t *testing.T) {
+	x, emit := fakeEmitter(t, "")
+	events := x.Debug.Events(context.Background())
+	emit <- "data: {\"type\":\"exitProcess\",\"code\":\"0x0\"}\n\n"
+	close(emit)
+	if e, ok := nextEvent(t, events); !ok || e.Kind != EventExitProcess {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	if _, ok := nextEvent(t, events); ok {
+		t.Error("channel not closed after the plugin hung up")
+	}
+}
+
+func TestEventsErrors(t *testing.T) {
+	x, _ := fakeEmitter(t, strconv.Itoa(WireSchemaVersion+1))
+	if _, err := x.Debug.TryEvents(context.Background()); !errors.Is(err, ErrSchemaVersion) {
+		t.Errorf("schema mismatch: %v, want ErrSchemaVersion", err)
+	}
+
+	old := fakePlugin(t, map[string]string{})
+	var status *HTTPStatusError
+	if _, err := old.X64dbg().Debug.TryEvents(context.Background()); !errors.As(err, &status) || !errors.Is(err, ErrUnknownEndpoint) {
+		t.Errorf("plugin without /Events: %v, want ErrUnknownEndpoint", err)
+	}
+}
@@ -9,9 +9,13 @@ import (
 type HexInt uint
 
 func (h *HexInt) UnmarshalJSON(data []byte) error {
-	s := strings.Trim(string(data), `"`)  // 去除 JSON 字符串的引号
-	s = strings.TrimPrefix(s, "0x")       // 去掉 "0x" 前缀
-	v, err := strconv.ParseInt(s, 16, 64) // 按十六进制解析
+	base := 16
+	if !strings.HasPrefix(string(data), `"`) {
+		base = 10 // 裸数字按 JSON 的十进制解析
+	}
+	s := strings.Trim(string(data), `"`)     // 去除 JSON 字符串的引号
+	s = strings.TrimPrefix(s, "0x")          // 去掉 "0x" 前缀
+	v, err := strconv.ParseUint(s, base, 64) // 内核地址高位为 1，不能用 ParseInt
 	*h = HexInt(v)
 	return err
 }
@@ -35,3 +39,12 @@ func (h *HexString) UnmarshalJSON(data []byte) error {
 	*h = HexString(decoded)
 	return err
 }
+
+// MarshalJSON 输出成 "0x..." 形式，和 UnmarshalJSON 对称，mcp tool 的结果也更好读
+func (h HexInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote("0x" + strconv.FormatUint(uint64(h), 16))), nil
+}
+
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(hex.EncodeToString(h))), nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// Flags is CFLAGS, EFLAGS under x32dbg and RFLAGS under x64dbg, bit by bit. Flags(v) decodes the
+// value of Register.GetCFLAGS and CFLAGS encodes it back.
+type Flags uint
+
+const (
+	FlagCF   Flags = 1 << 0
+	FlagPF   Flags = 1 << 2
+	FlagAF   Flags = 1 << 4
+	FlagZF   Flags = 1 << 6
+	FlagSF   Flags = 1 << 7
+	FlagTF   Flags = 1 << 8
+	FlagIF   Flags = 1 << 9
+	FlagDF   Flags = 1 << 10
+	FlagOF   Flags = 1 << 11
+	FlagIOPL Flags = 3 << 12
+	FlagRF   Flags = 1 << 16
+	FlagVM   Flags = 1 << 17
+	FlagAC   Flags = 1 << 18
+	FlagID   Flags = 1 << 21
+
+	// flagReserved 是第 1 位，cpu 上恒为 1
+	flagReserved Flags = 1 << 1
+)
+
+// flagNames 按 x64dbg 标志面板的顺序
+var flagNames = []struct {
+	name string
+	mask Flags
+}{
+	{"ZF", FlagZF}, {"PF", FlagPF}, {"AF", FlagAF},
+	{"OF", FlagOF}, {"SF", FlagSF}, {"DF", FlagDF},
+	{"CF", FlagCF}, {"TF", FlagTF}, {"IF", FlagIF},
+	{"IOPL", FlagIOPL}, {"RF", FlagRF}, {"VM", FlagVM}, {"AC", FlagAC}, {"ID", FlagID},
+}
+
+// FlagByName maps a flag name, case insensitive, to its mask: "zf" is FlagZF.
+func FlagByName(name string) (Flags, bool) {
+	for _, f := range flagNames {
+		if strings.EqualFold(f.name, name) {
+			return f.mask, true
+		}
+	}
+	return 0, false
+}
+
+// ParseFlags turns a list like "ZF, CF" or "zf|cf" into the mask of those flags.
+func ParseFlags(names string) (Flags, error) {
+	var mask Flags
+	for _, name := range strings.FieldsFunc(names, func(r rune) bool { return r == ',' || r == '|' || r == ' ' }) {
+		f, ok := FlagByName(name)
+		if !ok {
+			return 0, fmt.Errorf("x64dbg: unknown flag %q", name)
+		}
+		mask |= f
+	}
+	return mask, nil
+}
+
+func (f Flags) CF() bool { return f&FlagCF != 0 }
+func (f Flags) PF() bool { return f&FlagPF != 0 }
+func (f Flags) AF() bool { return f&FlagAF != 0 }
+func (f Flags) ZF() bool { return f&FlagZF != 0 }
+func (f Flags) SF() bool { return f&FlagSF != 0 }
+func (f Flags) TF() bool { return f&FlagTF != 0 }
+func (f Flags) IF() bool { return f&FlagIF != 0 }
+func (f Flags) DF() bool { return f&FlagDF != 0 }
+func (f Flags) OF() bool { return f&FlagOF != 0 }
+func (f Flags) RF() bool { return f&FlagRF != 0 }
+func (f Flags) VM() bool { return f&FlagVM != 0 }
+func (f Flags) AC() bool { return f&FlagAC != 0 }
+func (f Flags) ID() bool { return f&FlagID != 0 }
+
+// IOPL is the I/O privilege level, 0 to 3.
+func (f Flags) IOPL() int { return int(f&FlagIOPL) >> 12 }
+
+// With sets the flags of mask, f.With(FlagZF|FlagCF).
+func (f Flags) With(mask Flags) Flags { return f | mask }
+
+// Without clears the flags of mask.
+func (f Flags) Without(mask Flags) Flags { return f &^ mask }
+
+// WithIOPL replaces the I/O privilege level, only the low two bits of level count.
+func (f Flags) WithIOPL(level int) Flags { return f&^FlagIOPL | Flags(level&3)<<12 }
+
+// CFLAGS encodes f for Register.SetCFLAGS, with the always one bit 1 set.
+func (f Flags) CFLAGS() uint { return uint(f | flagReserved) }
+
+// String reads like x64dbg's flags panel: "ZF 1  PF 1  AF 0  OF 0  SF 0  DF 0  CF 0  TF 0  IF 1  IOPL 0 ...".
+func (f Flags) String() string {
+	fields := make([]string, 0, len(flagNames))
+	for _, flag := range flagNames {
+		v := 0
+		if flag.mask == FlagIOPL {
+			v = f.IOPL()
+		} else if f&flag.mask != 0 {
+			v = 1
+		}
+		fields = append(fields, fmt.Sprintf("%s %d", flag.name, v))
+	}
+	return strings.Join(fields, "  ")
+}
+
+// Flags reads every flag in one request.
+func (f flag) Flags() Flags {
+	return must(f.TryFlags())
+}
+func (f flag) TryFlags() (Flags, error) {
+	return f.FlagsContext(context.Background())
+}
+func (f flag) FlagsContext(ctx context.Context) (Flags, error) {
+	v, err := RegisterManager{f.client}.GetContext(ctx, CFLAGS)
+	return Flags(v), err
+}
+
+// SetFlags writes every flag at once.
+func (f flag) SetFlags(v Flags) { mylog.Check(f.TrySetFlags(v)) }
+func (f flag) TrySetFlags(v Flags) error {
+	return f.SetFlagsContext(context.Background(), v)
+}
+func (f flag) SetFlagsContext(ctx context.Context, v Flags) error {
+	_, err := RegisterManager{f.client}.SetContext(ctx, CFLAGS, v.CFLAGS())
+	return err
+}
+
+// Update sets the flags of set and clears those of clear in a single write of CFLAGS and returns the
+// new value, Update(FlagZF, FlagCF|FlagOF) instead of three Flag/Set round trips that each leave the
+// others half done. The debuggee is paused so nothing runs between the read and the write. When the
+// write fails the flags are still the old ones, and so is the value returned with the error.
+func (f flag) Update(set, clear Flags) Flags {
+	return must(f.TryUpdate(set, clear))
+}
+func (f flag) TryUpdate(set, clear Flags) (Flags, error) {
+	return f.UpdateContext(context.Background(), set, clear)
+}
+func (f flag) UpdateContext(ctx context.Context, set, clear Flags) (Flags, error) {
+	if set&clear != 0 {
+		return 0, fmt.Errorf("x64dbg: %s are both set and cleared", (set & clear).names())
+	}
+	old, err := f.FlagsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	v := old.Without(clear).With(set)
+	if v == old {
+		return v, nil
+	}
+	if err := f.SetFlagsContext(ctx, v); err != nil {
+		return old, err
+	}
+	return v, nil
+}
+
+// names lists the flags set in f, "ZF|CF".
+func (f Flags) names() string {
+	var names []string
+	for _, flag := range flagNames {
+		if f&flag.mask != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
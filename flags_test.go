@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	f := Flags(0x246)
+	if !f.ZF() || !f.PF() || !f.IF() || f.CF() || f.OF() || f.IOPL() != 0 {
+		t.Errorf("decode 0x246: %v", f)
+	}
+	if got := f.String(); got != "ZF 1  PF 1  AF 0  OF 0  SF 0  DF 0  CF 0  TF 0  IF 1  IOPL 0  RF 0  VM 0  AC 0  ID 0" {
+		t.Errorf("String = %q", got)
+	}
+	f = f.With(FlagCF | FlagID).Without(FlagZF).WithIOPL(3)
+	if f.CFLAGS() != 0x203207 || !f.ID() || f.IOPL() != 3 {
+		t.Errorf("encode = %#x", f.CFLAGS())
+	}
+	if Flags(0).CFLAGS() != 2 {
+		t.Error("reserved bit 1")
+	}
+	if mask, err := ParseFlags("zf, CF|of"); err != nil || mask != FlagZF|FlagCF|FlagOF {
+		t.Errorf("ParseFlags = %v, %v", mask.names(), err)
+	}
+	if _, err := ParseFlags("ZF,XF"); err == nil {
+		t.Error("ParseFlags accepted XF")
+	}
+
+	cflags := uint(0x246)
+	p := &fakeServer{}
+	p.handle("/Register/Get", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strconv.FormatUint(uint64(cflags), 10)))
+	})
+	p.handle("/Register/Set", func(w http.ResponseWriter, r *http.Request) {
+		v, _ := strconv.ParseUint(r.URL.Query().Get("value"), 16, 64)
+		cflags = uint(v)
+		w.Write([]byte("Register set successfully"))
+	})
+	flag := p.client(t).X64dbg().Flag
+
+	if got := flag.Flags(); got != 0x246 {
+		t.Errorf("Flags = %#x", uint(got))
+	}
+	if got := flag.Update(FlagCF|FlagOF|FlagTF, FlagZF); got != 0xb07 || cflags != 0xb07 {
+		t.Errorf("Update = %#x, CFLAGS %#x", uint(got), cflags)
+	}
+	flag.Update(FlagCF, 0)
+	if _, err := flag.TryUpdate(FlagZF, FlagZF|FlagCF); err == nil {
+		t.Error("Update set and cleared ZF")
+	}
+	flag.SetFlags(FlagIF)
+	want := []string{
+		"/Register/Get CFLAGS ",
+		"/Register/Get CFLAGS ",
+		"/Register/Set CFLAGS b07",
+		"/Register/Get CFLAGS ",
+		"/Register/Set CFLAGS 202",
+	}
+	requests := p.sent(func(r fakeRequest) string { return r.Path + " " + r.Query.Get("register") + " " + r.Query.Get("value") })
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests:\n%q\nwant\n%q", requests, want)
+	}
+
+	p.handle("/Register/Set", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Failed to set register", http.StatusInternalServerError)
+	})
+	if got, err := flag.TryUpdate(FlagZF, 0); err == nil || got != 0x202 || cflags != 0x202 {
+		t.Errorf("failed Update = %#x, %v, CFLAGS %#x", uint(got), err, cflags)
+	}
+}
@@ -4,4 +4,8 @@ go 1.25
 
 require github.com/ddkwork/golibrary v0.1.5-0.20250816073422-ec5c841d4409
 
-require github.com/google/go-cmp v0.7.0 // indirect
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+)
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// usage: x64dbgMCP [plugin url], same as x64dbg.py
+func main() {
+	c := DefaultClient
+	if len(os.Args) > 1 {
+		c = NewClient(os.Args[1])
+	}
+
+	// stdout 是 mcp 的传输通道，日志只写 log 文件
+	mylog.SetDebug(false)
+	mylog.Check(newMcpServer(c.X64dbg()).Serve(os.Stdin, os.Stdout))
+}
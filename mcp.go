@@ -1,24 +1,32 @@
 package main
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+
+	"github.com/ddkwork/golibrary/std/mylog"
 )
 
 type (
-	command      struct{}
-	register     struct{}
-	memory       struct{}
-	debug        struct{}
-	assembler    struct{}
-	stack        struct{}
-	flag         struct{}
-	pattern      struct{}
-	misc         struct{}
-	module       struct{}
-	disassembler struct{}
+	command      struct{ client *Client }
+	register     struct{ RegisterManager }
+	memory       struct{ client *Client }
+	debug        struct{ client *Client }
+	assembler    struct{ client *Client }
+	stack        struct{ client *Client }
+	flag         struct{ client *Client }
+	pattern      struct{ client *Client }
+	misc         struct{ client *Client }
+	module       struct{ client *Client }
+	disassembler struct{ client *Client }
+	breakpoints  struct{ client *Client }
+	thread       struct{ client *Client }
+	xref         struct{ client *Client }
+	symbol       struct{ client *Client }
 
 	x64dbg struct {
 		Command      command
@@ -32,6 +40,10 @@ type (
 		Misc         misc
 		Module       module
 		Disassembler disassembler
+		Breakpoints  breakpoints
+		Thread       thread
+		Xref         xref
+		Symbol       symbol
 	}
 )
 
@@ -244,7 +256,6 @@ static void registercommands()
     dbgcmdnew("SetWatchType", cbSetWatchType, true); // Set watch type
     dbgcmdnew("CheckWatchdog", cbCheckWatchdog, true); // Watchdog
 
-
     //variables
     dbgcmdnew("varnew,var", cbInstrVar, false); //make a variable arg1:name,[arg2:value]
     dbgcmdnew("vardel", cbInstrVarDel, false); //delete a variable, arg1:variable name
@@ -443,264 +454,328 @@ static void registercommands()
 
 */
 
-func (x x64dbg) Restart() { x.Command.Exec("restartadmin") }
+func (x x64dbg) Restart()          { mylog.Check(x.TryRestart()) }
+func (x x64dbg) TryRestart() error { return x.RestartContext(context.Background()) }
+func (x x64dbg) RestartContext(ctx context.Context) error {
+	_, err := x.Command.ExecContext(ctx, "restartadmin")
+	return err
+}
+
 func (x x64dbg) FindAsm(address int, instruction string) (size int, data HexBytes) {
-	result := x.Command.Exec("findasm " + strconv.Quote(instruction) + "," + fmt.Sprintf("0x%x", address))
-	if result == "" {
-		return 0, nil
+	size, data, err := x.TryFindAsm(address, instruction)
+	mylog.Check(err)
+	return size, data
+}
+func (x x64dbg) TryFindAsm(address int, instruction string) (size int, data HexBytes, err error) {
+	return x.FindAsmContext(context.Background(), address, instruction)
+}
+func (x x64dbg) FindAsmContext(ctx context.Context, address int, instruction string) (size int, data HexBytes, err error) {
+	result, err := x.Command.ExecContext(ctx, "findasm "+strconv.Quote(instruction)+","+fmt.Sprintf("0x%x", address))
+	if err != nil || result == "" {
+		return 0, nil, err
 	}
 	var r assemblerResult
-	json.Unmarshal([]byte(result), &r)
-	return r.Size, r.Data
+	if err := json.Unmarshal([]byte(result), &r); err != nil {
+		return 0, nil, &DecodeError{Endpoint: "ExecCommand", Type: "assemblerResult", Body: result, Err: err}
+	}
+	return r.Size, r.Data, nil
 }
 
-func (command) Exec(cmd string) string {
-	return request[string]("ExecCommand", map[string]string{"cmd": cmd})
+// TryGet and the Context forms go through the register name, the generated GetXXX methods stay for typed access.
+// Once the client knows the bitness they refuse registers the debuggee has not, see Ptr.
+func (m RegisterManager) TryGet(reg RegisterEnum) (uint, error) {
+	return m.GetContext(context.Background(), reg)
 }
-
-func (debug) Active() bool {
-	return request[bool]("IsDebugActive", nil)
+func (m RegisterManager) GetContext(ctx context.Context, reg RegisterEnum) (uint, error) {
+	if err := m.checkKnown(reg); err != nil {
+		return 0, err
+	}
+	return tryRequest[uint](m.client, ctx, "Register/Get", map[string]string{"register": reg.String()})
 }
-
-func (debug) Debugging() bool {
-	return request[bool]("Is_Debugging", nil)
+func (m RegisterManager) TrySet(reg RegisterEnum, value uint) (bool, error) {
+	return m.SetContext(context.Background(), reg, value)
 }
-
-func (memory) Read(address int, size uint) HexBytes {
-	return request[HexBytes]("Memory/Read", map[string]string{"addr": fmt.Sprintf("0x%x", address), "size": fmt.Sprintf("%d", size)})
+func (m RegisterManager) SetContext(ctx context.Context, reg RegisterEnum, value uint) (bool, error) {
+	if err := m.checkKnown(reg); err != nil {
+		return false, err
+	}
+	return tryRequest[bool](m.client, ctx, "Register/Set", map[string]string{"register": reg.String(), "value": strconv.FormatUint(uint64(value), 16)})
 }
 
-func (memory) Write(address int, data HexBytes) bool {
-	return request[bool]("Memory/Write", map[string]string{"addr": fmt.Sprintf("0x%x", address), "data": hex.EncodeToString(data)})
+func (c command) Exec(cmd string) string {
+	return must(c.TryExec(cmd))
 }
-
-func (memory) IsValidPtr(address int) bool {
-	return request[bool]("Memory/IsValidPtr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (c command) TryExec(cmd string) (string, error) {
+	return c.ExecContext(context.Background(), cmd)
+}
+func (c command) ExecContext(ctx context.Context, cmd string) (string, error) {
+	return tryPost[string](c.client, ctx, "ExecCommand", nil, cmd)
 }
 
-func (memory) GetProtectFlag(address int) string { //todo gen enum flag
-	return request[string]("Memory/GetProtect", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d debug) Active() bool {
+	return must(d.TryActive())
+}
+func (d debug) TryActive() (bool, error) {
+	return d.ActiveContext(context.Background())
+}
+func (d debug) ActiveContext(ctx context.Context) (bool, error) {
+	return tryRequest[bool](d.client, ctx, "IsDebugActive", nil)
 }
 
-type void any
+func (d debug) Debugging() bool {
+	return must(d.TryDebugging())
+}
+func (d debug) TryDebugging() (bool, error) {
+	return d.DebuggingContext(context.Background())
+}
+func (d debug) DebuggingContext(ctx context.Context) (bool, error) {
+	return tryRequest[bool](d.client, ctx, "Is_Debugging", nil)
+}
 
-func (debug) Run()      { request[void]("Debug/Run", nil) }
-func (debug) Pause()    { request[void]("Debug/Pause", nil) }
-func (debug) Stop()     { request[void]("Debug/Stop", nil) }
-func (debug) StepIn()   { request[void]("Debug/StepIn", nil) }
-func (debug) StepOver() { request[void]("Debug/StepOver", nil) }
-func (debug) StepOut()  { request[void]("Debug/StepOut", nil) }
-func (debug) SetBreakpoint(address int) bool { //todo 添加硬件断点
-	return request[bool]("Debug/SetBreakpoint", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (m memory) Read(address int, size uint) HexBytes {
+	return must(m.TryRead(address, size))
+}
+func (m memory) TryRead(address int, size uint) (HexBytes, error) {
+	return m.ReadContext(context.Background(), address, size)
 }
-func (debug) DeleteBreakpoint(address int) bool {
-	return request[bool]("Debug/DeleteBreakpoint", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (m memory) ReadContext(ctx context.Context, address int, size uint) (HexBytes, error) {
+	return tryRequest[HexBytes](m.client, ctx, "Memory/Read", map[string]string{"addr": fmt.Sprintf("0x%x", address), "size": fmt.Sprintf("%d", size)})
 }
 
-type assemblerResult struct {
-	Status string   `json:"success"`
-	Size   int      `json:"size"`
-	Data   HexBytes `json:"bytes"`
+func (m memory) Write(address int, data HexBytes) bool {
+	return must(m.TryWrite(address, data))
+}
+func (m memory) TryWrite(address int, data HexBytes) (bool, error) {
+	return m.WriteContext(context.Background(), address, data)
+}
+func (m memory) WriteContext(ctx context.Context, address int, data HexBytes) (bool, error) {
+	return tryPost[bool](m.client, ctx, "Memory/Write", map[string]string{"addr": fmt.Sprintf("0x%x", address)}, hex.EncodeToString(data))
 }
 
-func (assembler) Assemble(address int, instruction string) assemblerResult {
-	return request[assemblerResult]("Assembler/Assemble", map[string]string{"addr": fmt.Sprintf("0x%x", address), "instruction": instruction})
+func (m memory) IsValidPtr(address int) bool {
+	return must(m.TryIsValidPtr(address))
 }
-func (assembler) AssembleMem(address int, instructionOpcodes HexBytes) bool {
-	return request[bool]("Assembler/AssembleMem", map[string]string{"addr": fmt.Sprintf("0x%x", address), "instruction": hex.EncodeToString(instructionOpcodes)})
+func (m memory) TryIsValidPtr(address int) (bool, error) {
+	return m.IsValidPtrContext(context.Background(), address)
+}
+func (m memory) IsValidPtrContext(ctx context.Context, address int) (bool, error) {
+	return tryRequest[bool](m.client, ctx, "Memory/IsValidPtr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
 
-func (stack) Pop() HexInt { //todo 改成泛型
-	return request[HexInt]("Stack/Pop", nil)
+func (m memory) GetProtectFlag(address int) PageProtection {
+	return must(m.TryGetProtectFlag(address))
 }
-func (stack) Push(value uint) HexInt {
-	return request[HexInt]("Stack/Push", map[string]string{"value": fmt.Sprintf("0x%x", value)})
+func (m memory) TryGetProtectFlag(address int) (PageProtection, error) {
+	return m.GetProtectFlagContext(context.Background(), address)
 }
-func (stack) Peek(offset int) HexInt {
-	return request[HexInt]("Stack/Peek", map[string]string{"offset": fmt.Sprintf("0x%x", offset)})
+func (m memory) GetProtectFlagContext(ctx context.Context, address int) (PageProtection, error) {
+	return tryRequest[PageProtection](m.client, ctx, "Memory/GetProtect", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
 
-func (disassembler) AtAddress(address int) disassemblerAddress {
-	return request[disassemblerAddress]("Disasm/GetInstruction", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+type void any
+
+func (d debug) Run()          { mylog.Check(d.TryRun()) }
+func (d debug) TryRun() error { return d.RunContext(context.Background()) }
+func (d debug) RunContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/Run", nil)
+	return err
 }
-func (disassembler) AtAddressWithSize(address int, size int) []disassemblerAddress {
-	if size < 1 || size > 100 {
-		panic("count should be between 1 and 100 bytes buffer")
-	}
-	return request[[]disassemblerAddress]("Disasm/GetInstructionRange", map[string]string{"addr": fmt.Sprintf("0x%x", address), "count": fmt.Sprintf("%d", size)})
+func (d debug) Pause()          { mylog.Check(d.TryPause()) }
+func (d debug) TryPause() error { return d.PauseContext(context.Background()) }
+func (d debug) PauseContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/Pause", nil)
+	return err
+}
+func (d debug) Stop()          { mylog.Check(d.TryStop()) }
+func (d debug) TryStop() error { return d.StopContext(context.Background()) }
+func (d debug) StopContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/Stop", nil)
+	return err
+}
+func (d debug) StepIn()          { mylog.Check(d.TryStepIn()) }
+func (d debug) TryStepIn() error { return d.StepInContext(context.Background()) }
+func (d debug) StepInContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/StepIn", nil)
+	return err
 }
-func (disassembler) AtRip() disassembleRip {
-	return request[disassembleRip]("Disasm/GetInstructionAtRIP", nil)
+func (d debug) StepOver()          { mylog.Check(d.TryStepOver()) }
+func (d debug) TryStepOver() error { return d.StepOverContext(context.Background()) }
+func (d debug) StepOverContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/StepOver", nil)
+	return err
 }
-func (disassembler) AtRipFromStepIn() disassembleRipWithSetupIn {
-	return request[disassembleRipWithSetupIn]("Disasm/StepInWithDisasm", nil)
+func (d debug) StepOut()          { mylog.Check(d.TryStepOut()) }
+func (d debug) TryStepOut() error { return d.StepOutContext(context.Background()) }
+func (d debug) StepOutContext(ctx context.Context) error {
+	_, err := tryRequest[void](d.client, ctx, "Debug/StepOut", nil)
+	return err
 }
 
-type disassemblerAddress struct {
-	Address     HexInt `json:"address"`
-	Instruction string `json:"instruction"`
-	Size        int    `json:"size"`
+// SetBreakpoint 只下软件断点，硬件、内存、dll、异常断点见 Breakpoints
+func (d debug) SetBreakpoint(address int) bool {
+	return must(d.TrySetBreakpoint(address))
 }
-type disassembleRip struct {
-	Rip         HexInt `json:"rip"`
-	Instruction string `json:"instruction"`
-	Size        int    `json:"size"`
+func (d debug) TrySetBreakpoint(address int) (bool, error) {
+	return d.SetBreakpointContext(context.Background(), address)
 }
-type disassembleRipWithSetupIn struct {
-	StepResult  string `json:"step_result"`
-	Rip         HexInt `json:"rip"`
-	Instruction string `json:"instruction"`
-	Size        int    `json:"size"`
+func (d debug) SetBreakpointContext(ctx context.Context, address int) (bool, error) {
+	return tryRequest[bool](d.client, ctx, "Debug/SetBreakpoint", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
-
-// Get flag: Flag name (ZF, OF, CF, PF, SF, TF, AF, DF, IF)
-// todo gen enum flag
-func (flag) Get(name string) bool {
-	return request[bool]("Flag/Get", map[string]string{"flag": name})
+func (d debug) DeleteBreakpoint(address int) bool {
+	return must(d.TryDeleteBreakpoint(address))
 }
-
-func (flag) Set(name string, value bool) string {
-	return request[string]("Flag/Set", map[string]string{"flag": name, "value": fmt.Sprintf("%v", value)})
+func (d debug) TryDeleteBreakpoint(address int) (bool, error) {
+	return d.DeleteBreakpointContext(context.Background(), address)
 }
-
-// FindMemory todo 特征码支持字节切片类型
-func (pattern) FindMemory(start int, size int, pattern string) (address HexInt) {
-	return request[HexInt]("Pattern/FindMem", map[string]string{"start": fmt.Sprintf("0x%x", start), "size": fmt.Sprintf("%d", size), "pattern": pattern})
+func (d debug) DeleteBreakpointContext(ctx context.Context, address int) (bool, error) {
+	return tryRequest[bool](d.client, ctx, "Debug/DeleteBreakpoint", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
 
-func (misc) ParseExpression(expression string) (value uint) {
-	return request[uint]("Misc/ParseExpression", map[string]string{"expression": expression})
+func (a assembler) Assemble(address int, instruction string) assemblerResult {
+	return must(a.TryAssemble(address, instruction))
 }
-
-func (misc) GetApiAddressFromModule(module string, api string) (address HexInt) {
-	return request[HexInt]("Misc/RemoteGetProcAddress", map[string]string{"module": module, "api": api})
+func (a assembler) TryAssemble(address int, instruction string) (assemblerResult, error) {
+	return a.AssembleContext(context.Background(), address, instruction)
 }
-
-type memoryBase struct {
-	BaseAddress HexInt `json:"base_address"`
-	Size        uint   `json:"size"`
+func (a assembler) AssembleContext(ctx context.Context, address int, instruction string) (assemblerResult, error) {
+	return tryRequest[assemblerResult](a.client, ctx, "Assembler/Assemble", map[string]string{"addr": fmt.Sprintf("0x%x", address), "instruction": instruction})
 }
-
-func (memory) FindBaseByAddress(address int) memoryBase {
-	return request[memoryBase]("MemoryBase", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (a assembler) AssembleMem(address int, instructionOpcodes HexBytes) bool {
+	return must(a.TryAssembleMem(address, instructionOpcodes))
 }
-
-type moduleInfo struct {
-	BaseAddress  HexInt `json:"base_address"`
-	Size         uint   `json:"size"`
-	Entry        HexInt `json:"entry"`
-	SectionCount int    `json:"section_count"`
-	Name         string
-	Path         string
+func (a assembler) TryAssembleMem(address int, instructionOpcodes HexBytes) (bool, error) {
+	return a.AssembleMemContext(context.Background(), address, instructionOpcodes)
 }
-
-type moduleSectionInfo struct {
-	Address HexInt `json:"address"`
-	Size    uint   `json:"size"`
-	Name    string
+func (a assembler) AssembleMemContext(ctx context.Context, address int, instructionOpcodes HexBytes) (bool, error) {
+	return tryRequest[bool](a.client, ctx, "Assembler/AssembleMem", map[string]string{"addr": fmt.Sprintf("0x%x", address), "instruction": hex.EncodeToString(instructionOpcodes)})
 }
 
-type moduleExport struct {
-	Ordinal         HexInt `json:"ordinal"`
-	Rva             HexInt `json:"rva"`
-	Va              HexInt `json:"va"`
-	Forwarded       bool   `json:"forwarded"`
-	ForwardName     string
-	Name            string
-	UndecoratedName string
+func (s stack) Pop() HexInt { //todo 改成泛型
+	return must(s.TryPop())
 }
-
-type moduleImport struct {
-	IatRva          HexInt `json:"iat_rva"`
-	IatVa           HexInt `json:"iat_va"`
-	Ordinal         HexInt `json:"ordinal"`
-	Name            string
-	UndecoratedName string
+func (s stack) TryPop() (HexInt, error) {
+	return s.PopContext(context.Background())
+}
+func (s stack) PopContext(ctx context.Context) (HexInt, error) {
+	return tryRequest[HexInt](s.client, ctx, "Stack/Pop", nil)
+}
+func (s stack) Push(value uint) HexInt {
+	return must(s.TryPush(value))
+}
+func (s stack) TryPush(value uint) (HexInt, error) {
+	return s.PushContext(context.Background(), value)
+}
+func (s stack) PushContext(ctx context.Context, value uint) (HexInt, error) {
+	return tryRequest[HexInt](s.client, ctx, "Stack/Push", map[string]string{"value": fmt.Sprintf("0x%x", value)})
+}
+func (s stack) Peek(offset int) HexInt {
+	return must(s.TryPeek(offset))
+}
+func (s stack) TryPeek(offset int) (HexInt, error) {
+	return s.PeekContext(context.Background(), offset)
+}
+func (s stack) PeekContext(ctx context.Context, offset int) (HexInt, error) {
+	return tryRequest[HexInt](s.client, ctx, "Stack/Peek", map[string]string{"offset": fmt.Sprintf("0x%x", offset)})
 }
 
-// todo implement other method in cpp server
-
-func (module) InfoFromAddr(address int) moduleInfo {
-	return request[moduleInfo]("Module/InfoFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtAddress(address int) disassemblerAddress {
+	return must(d.TryAtAddress(address))
+}
+func (d disassembler) TryAtAddress(address int) (disassemblerAddress, error) {
+	return d.AtAddressContext(context.Background(), address)
 }
-func (module) InfoFromName(name string) moduleInfo {
-	return request[moduleInfo]("Module/InfoFromName", map[string]string{"name": name})
+func (d disassembler) AtAddressContext(ctx context.Context, address int) (disassemblerAddress, error) {
+	return tryRequest[disassemblerAddress](d.client, ctx, "Disasm/GetInstruction", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
-func (module) BaseFromAddr(address int) HexInt {
-	return request[HexInt]("Module/BaseFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtAddressWithSize(address int, size int) []disassemblerAddress {
+	return must(d.TryAtAddressWithSize(address, size))
 }
-func (module) BaseFromName(name string) HexInt {
-	return request[HexInt]("Module/BaseFromName", map[string]string{"name": name})
+func (d disassembler) TryAtAddressWithSize(address int, size int) ([]disassemblerAddress, error) {
+	return d.AtAddressWithSizeContext(context.Background(), address, size)
+}
+func (d disassembler) AtAddressWithSizeContext(ctx context.Context, address int, size int) ([]disassemblerAddress, error) {
+	if size < 1 || size > 100 {
+		return nil, errors.New("count should be between 1 and 100 bytes buffer")
+	}
+	return tryRequest[[]disassemblerAddress](d.client, ctx, "Disasm/GetInstructionRange", map[string]string{"addr": fmt.Sprintf("0x%x", address), "count": fmt.Sprintf("%d", size)})
 }
-func (module) SizeFromAddr(address int) HexInt {
-	return request[HexInt]("Module/SizeFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtRip() disassembleRip {
+	return must(d.TryAtRip())
 }
-func (module) SizeFromName(name string) HexInt {
-	return request[HexInt]("Module/SizeFromName", map[string]string{"name": name})
+func (d disassembler) TryAtRip() (disassembleRip, error) {
+	return d.AtRipContext(context.Background())
 }
-func (module) NameFromAddr(address int) string {
-	return request[string]("Module/NameFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtRipContext(ctx context.Context) (disassembleRip, error) {
+	return tryRequest[disassembleRip](d.client, ctx, "Disasm/GetInstructionAtRIP", nil)
 }
-func (module) PathFromAddr(address int) string {
-	return request[string]("Module/PathFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtRipFromStepIn() disassembleRipWithSetupIn {
+	return must(d.TryAtRipFromStepIn())
 }
-func (module) PathFromName(name string) string {
-	return request[string]("Module/PathFromName", map[string]string{"name": name})
+func (d disassembler) TryAtRipFromStepIn() (disassembleRipWithSetupIn, error) {
+	return d.AtRipFromStepInContext(context.Background())
 }
-func (module) EntryFromAddr(address int) HexInt {
-	return request[HexInt]("Module/EntryFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (d disassembler) AtRipFromStepInContext(ctx context.Context) (disassembleRipWithSetupIn, error) {
+	return tryRequest[disassembleRipWithSetupIn](d.client, ctx, "Disasm/StepInWithDisasm", nil)
 }
-func (module) EntryFromName(name string) HexInt {
-	return request[HexInt]("Module/EntryFromName", map[string]string{"name": name})
+
+// Get flag: Flag name (ZF, OF, CF, PF, SF, TF, AF, DF, IF), Flags reads them all at once
+func (f flag) Get(name string) bool {
+	return must(f.TryGet(name))
 }
-func (module) SectionCountFromAddr(address int) HexInt {
-	return request[HexInt]("Module/SectionCountFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (f flag) TryGet(name string) (bool, error) {
+	return f.GetContext(context.Background(), name)
 }
-func (module) SectionCountFromName(name string) HexInt {
-	return request[HexInt]("Module/SectionCountFromName", map[string]string{"name": name})
+func (f flag) GetContext(ctx context.Context, name string) (bool, error) {
+	return tryRequest[bool](f.client, ctx, "Flag/Get", map[string]string{"flag": name})
 }
-func (module) SectionFromAddr(address int, number int) moduleSectionInfo {
-	return request[moduleSectionInfo]("Module/SectionFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address), "number": fmt.Sprintf("%d", number)})
+
+func (f flag) Set(name string, value bool) string {
+	return must(f.TrySet(name, value))
 }
-func (module) SectionFromName(name string, number int) moduleSectionInfo {
-	return request[moduleSectionInfo]("Module/SectionFromName", map[string]string{"name": name, "number": fmt.Sprintf("%d", number)})
+func (f flag) TrySet(name string, value bool) (string, error) {
+	return f.SetContext(context.Background(), name, value)
 }
-func (module) SectionListFromAddr(address int) []moduleSectionInfo {
-	return request[[]moduleSectionInfo]("Module/SectionListFromAddr", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+func (f flag) SetContext(ctx context.Context, name string, value bool) (string, error) {
+	return tryRequest[string](f.client, ctx, "Flag/Set", map[string]string{"flag": name, "value": fmt.Sprintf("%v", value)})
 }
-func (module) SectionListFromName(name string) []moduleSectionInfo {
-	return request[[]moduleSectionInfo]("Module/SectionListFromName", map[string]string{"name": name})
+
+// FindMemory todo 特征码支持字节切片类型
+func (p pattern) FindMemory(start int, size int, pattern string) (address HexInt) {
+	return must(p.TryFindMemory(start, size, pattern))
 }
-func (module) GetMainModuleInfo() moduleInfo {
-	return request[moduleInfo]("Module/GetMainModuleInfo", nil)
+func (p pattern) TryFindMemory(start int, size int, pattern string) (HexInt, error) {
+	return p.FindMemoryContext(context.Background(), start, size, pattern)
 }
-func (module) GetMainModuleBase() HexInt {
-	return request[HexInt]("Module/GetMainModuleBase", nil)
+func (p pattern) FindMemoryContext(ctx context.Context, start int, size int, pattern string) (HexInt, error) {
+	return tryRequest[HexInt](p.client, ctx, "Pattern/FindMem", map[string]string{"start": fmt.Sprintf("0x%x", start), "size": fmt.Sprintf("%d", size), "pattern": pattern})
 }
-func (module) GetMainModuleSize() HexInt {
-	return request[HexInt]("Module/GetMainModuleSize", nil)
+
+func (m misc) ParseExpression(expression string) (value uint) {
+	return must(m.TryParseExpression(expression))
 }
-func (module) GetMainModuleEntry() HexInt {
-	return request[HexInt]("Module/GetMainModuleEntry", nil)
+func (m misc) TryParseExpression(expression string) (uint, error) {
+	return m.ParseExpressionContext(context.Background(), expression)
 }
-func (module) GetMainModuleSectionCount() int {
-	return request[int]("Module/GetMainModuleSectionCount", nil)
+func (m misc) ParseExpressionContext(ctx context.Context, expression string) (uint, error) {
+	return tryRequest[uint](m.client, ctx, "Misc/ParseExpression", map[string]string{"expression": expression})
 }
-func (module) GetMainModuleName() string {
-	return request[string]("Module/GetMainModuleName", nil)
+
+func (m misc) GetApiAddressFromModule(module string, api string) (address HexInt) {
+	return must(m.TryGetApiAddressFromModule(module, api))
 }
-func (module) GetMainModulePath() string {
-	return request[string]("Module/GetMainModulePath", nil)
+func (m misc) TryGetApiAddressFromModule(module string, api string) (HexInt, error) {
+	return m.GetApiAddressFromModuleContext(context.Background(), module, api)
 }
-func (module) GetMainModuleSectionList() []moduleSectionInfo {
-	return request[[]moduleSectionInfo]("Module/GetMainModuleSectionList", nil)
+func (m misc) GetApiAddressFromModuleContext(ctx context.Context, module string, api string) (HexInt, error) {
+	return tryRequest[HexInt](m.client, ctx, "Misc/RemoteGetProcAddress", map[string]string{"module": module, "api": api})
 }
-func (module) GetList() []moduleInfo {
-	return request[[]moduleInfo]("Module/GetList", nil)
+
+func (m memory) FindBaseByAddress(address int) memoryBase {
+	return must(m.TryFindBaseByAddress(address))
 }
-func (module) GetExports(mod moduleInfo) []moduleExport {
-	return request[[]moduleExport]("Module/GetExports", map[string]string{"mod": fmt.Sprintf("%v", mod)})
+func (m memory) TryFindBaseByAddress(address int) (memoryBase, error) {
+	return m.FindBaseByAddressContext(context.Background(), address)
 }
-func (module) GetImports(mod moduleInfo) []moduleImport {
-	return request[[]moduleImport]("Module/GetImports", map[string]string{"mod": fmt.Sprintf("%v", mod)})
+func (m memory) FindBaseByAddressContext(ctx context.Context, address int) (memoryBase, error) {
+	return tryRequest[memoryBase](m.client, ctx, "MemoryBase", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -11,7 +12,7 @@ import (
 func TestGenRegister(t *testing.T) {
 	g := stream.NewGeneratedFile()
 	g.P("type RegisterEnum int")
-	g.P("type RegisterManager struct{}")
+	g.P("type RegisterManager struct{ client *Client }")
 	for api := range strings.Lines(apis) {
 		api = strings.TrimSpace(api)
 		if api == "" {
@@ -20,77 +21,106 @@ func TestGenRegister(t *testing.T) {
 		if strings.HasPrefix(api, "//") {
 			continue
 		}
-		g.P(`func (m RegisterManager) `, api, "{")
-
-		const (
-			getUrlPath = "Register/Get"
-			setUrlPath = "Register/Set"
-			paramName  = "register"
-		)
-
-		g.AddImport("strconv")
-		if strings.HasPrefix(api, "Get") {
-			split := strings.Split(api, " ")
-			reg := split[0]
-			reg = strings.TrimPrefix(reg, "Get")
-			reg = strings.TrimSpace(reg)
-			reg = strings.TrimSuffix(reg, "()")
-			retType := split[1]
-			g.P("return request[",
-				retType,
-				"](",
-				strconv.Quote(getUrlPath),
-				", map[string]string{",
-				strconv.Quote(paramName),
-				": ",
-				strconv.Quote(reg),
-				"})")
+		// 每个寄存器一组 Get/TryGet/GetContext 和 Set 的三件套，都走 GetContext/SetContext，
+		// 和按 RegisterEnum 访问一样先检查调试对象的位数
+		g.AddImport("context")
+		if name, retType, found := strings.Cut(api, "() "); found && strings.HasPrefix(name, "Get") {
+			reg := strings.TrimPrefix(name, "Get")
+			g.P("func (m RegisterManager) Get", reg, "() ", retType, " { return must(m.TryGet", reg, "()) }")
+			g.P("func (m RegisterManager) TryGet", reg, "() (", retType, ", error) { return m.Get", reg, "Context(context.Background()) }")
+			g.P("func (m RegisterManager) Get", reg, "Context(ctx context.Context) (", retType, ", error) {")
+			g.P("v, err := m.GetContext(ctx, ", reg, ")")
+			g.P("return ", retType, "(v), err")
+			g.P("}")
+		}
+		if name, after, found := strings.Cut(api, "(v "); found && strings.HasPrefix(name, "Set") {
+			reg := strings.TrimPrefix(name, "Set")
+			paramType, _, _ := strings.Cut(after, ")")
+			g.P("func (m RegisterManager) Set", reg, "(v ", paramType, ") bool { return must(m.TrySet", reg, "(v)) }")
+			g.P("func (m RegisterManager) TrySet", reg, "(v ", paramType, ") (bool, error) { return m.Set", reg, "Context(context.Background(), v) }")
+			g.P("func (m RegisterManager) Set", reg, "Context(ctx context.Context, v ", paramType, ") (bool, error) {")
+			g.P("return m.SetContext(ctx, ", reg, ", uint(v))")
+			g.P("}")
 		}
+	}
+	g.P()
+	g.AddImport("github.com/ddkwork/golibrary/std/mylog")
+	g.P(getSet)
 
-		if strings.HasPrefix(api, "Set") {
-			//func (m *RegisterManager) SetDR0(v uint) bool {
-			//	return request[bool](tagSetRegister, map[string]string{"register": "DR0", "value": strconv.FormatUint(uint64(v), 16)})
-			//}
-			api = strings.TrimSpace(api)
-			api = strings.TrimPrefix(api, "Set")
-			reg, after, found := strings.Cut(api, "(")
-			if found {
-				_, retType, f := strings.Cut(after, ")")
-				if f {
-					retType = strings.TrimSpace(retType) //must be bool
-					//split := strings.Split(before, " ")
-					//name := split[0]
-					//paramType := strings.TrimSpace(split[1])
+	g.AddImport("strings")
+	g.P(enum)
 
-					g.P("return request[bool](",
-						strconv.Quote(setUrlPath),
-						", map[string]string{",
-						strconv.Quote(paramName),
-						": ",
-						strconv.Quote(reg),
-						", ",
-						strconv.Quote("value"),
-						": strconv.FormatUint(uint64(v), 16)})")
+	// 寄存器名称表，给 mcp tool 这类按名字访问寄存器的场景用
+	g.P("var registerEnumNames = [...]string{")
+	for line := range strings.Lines(enum) {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "const (" || line == ")" {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		g.P(strconv.Quote(name), ",")
+	}
+	g.P("}")
+	g.P()
 
-				}
+	// 按架构分开的表：x32dbg 没有 R 开头的寄存器和 SIL、DIL、BPL、SPL，宽度取自 apis 的返回类型
+	sizes := map[string]string{}
+	for api := range strings.Lines(apis) {
+		name, retType, found := strings.Cut(strings.TrimSpace(api), "() ")
+		if found && strings.HasPrefix(name, "Get") {
+			sizes[strings.TrimPrefix(name, "Get")] = retType
+		}
+	}
+	var names []string
+	for line := range strings.Lines(enum) {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "const (" || line == ")" {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		names = append(names, name)
+	}
+	x64Only := func(name string) bool {
+		return strings.HasPrefix(name, "R") || slices.Contains([]string{"SIL", "DIL", "BPL", "SPL"}, name)
+	}
+	g.P("// registerSizes is the width of each register in bytes, 0 for the pointer sized ones: DR0-DR7 and the C aliases")
+	g.P("var registerSizes = [...]int{")
+	for _, name := range names {
+		size := map[string]int{"uint8": 1, "uint16": 2, "uint32": 4, "uint64": 8}[sizes[name]]
+		g.P(name, ": ", size, ",")
+	}
+	g.P("}")
+	g.P()
+	for _, arch := range []struct {
+		table string
+		x64   bool
+	}{{"registersX86", false}, {"registersX64", true}} {
+		g.P("var ", arch.table, " = []RegisterEnum{")
+		for _, name := range names {
+			if arch.x64 || !x64Only(name) {
+				g.P(name, ",")
 			}
-
 		}
-
 		g.P("}")
+		g.P()
 	}
-	g.P()
-	g.P(getSet)
 
-	g.AddImport("encoding/json")
-	g.AddImport("fmt")
-	g.AddImport("io")
-	g.AddImport("net/http")
-	g.AddImport("strings")
-	g.AddImport("time")
-	g.AddImport("github.com/ddkwork/golibrary/std/mylog")
-	g.P(common)
-	g.P(enum)
+	g.AddImport("strconv")
+	g.P("func (r RegisterEnum) String() string {")
+	g.P("if r < 0 || int(r) >= len(registerEnumNames) {")
+	g.P(`return "RegisterEnum(" + strconv.Itoa(int(r)) + ")"`)
+	g.P("}")
+	g.P("return registerEnumNames[r]")
+	g.P("}")
+	g.P()
+	g.P("func RegisterEnumByName(name string) (RegisterEnum, bool) {")
+	g.P("for i, s := range registerEnumNames {")
+	g.P("if strings.EqualFold(s, name) {")
+	g.P("return RegisterEnum(i), true")
+	g.P("}")
+	g.P("}")
+	g.P("return 0, false")
+	g.P("}")
 	g.InsertPackageWithImports("main")
 	stream.WriteGoFile("register.go", g.String())
 
@@ -273,188 +303,6 @@ GetCBP() uint
 SetCBP(v uint) bool
 GetCFLAGS() uint
 SetCFLAGS(v uint) bool 
-`
-
-	common = `
-
-const DefaultX64dbgServer = "http://127.0.0.1:8888/"
-
-var client = &http.Client{
-	Timeout: 15 * time.Second,
-	Transport: &http.Transport{
-		DisableKeepAlives: true,
-	},
-}
-
-//	Type type Ordered interface {
-//		~int | ~int8 | ~int16 | ~int32 | ~int64 |
-//			~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
-//			~float32 | ~float64 |
-//			~string
-//	}
-type Type interface {
-	cmp.Ordered |
-		bool |
-		[]byte |
-		moduleInfo |
-		[]moduleInfo |
-		moduleSectionInfo |
-		[]moduleSectionInfo |
-		moduleExport |
-		[]moduleExport |
-		moduleImport |
-		[]moduleImport |
-		memoryBase |
-		disassemblerAddress |
-		disassembleRip |
-		disassembleRipWithSetupIn |
-		assemblerResult |
-		void
-}
-
-func request[T Type](endpoint string, params map[string]string) T {
-	x64dbgServerURL := DefaultX64dbgServer
-	url := x64dbgServerURL + endpoint
-
-	// 添加查询参数
-	if len(params) > 0 {
-		query := ""
-		for key, value := range params {
-			query += key + "=" + value + "&"
-		}
-		url += "?" + strings.TrimSuffix(query, "&")
-	}
-
-	resp := mylog.Check2(client.Get(url))
-	defer func() {
-		mylog.Check(resp.Body.Close())
-	}()
-
-	body := mylog.Check2(io.ReadAll(resp.Body))
-
-	if resp.StatusCode != http.StatusOK {
-		mylog.Check(fmt.Sprintf("Error %d: %s", resp.StatusCode, string(body)))
-	}
-
-	str := strings.TrimSpace(string(body))
-	base := 10
-	if strings.HasPrefix(str, "0x") {
-		base = 16
-	}
-	str = strings.TrimPrefix(str, "0x")
-	var zero T
-	switch v := any(zero).(type) {
-	case bool:
-		if strings.EqualFold(str, "true") {
-			return any(true).(T)
-		}
-		if strings.EqualFold(str, "false") {
-			return any(false).(T)
-		}
-	case []byte:
-		b := mylog.Check2(hex.DecodeString(str))
-		return any(b).(T)
-	case int:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 64))
-		return any(value).(T)
-	case int8:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 8))
-		return any(value).(T)
-	case int16:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 16))
-		return any(value).(T)
-	case int32:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 32))
-		return any(value).(T)
-	case int64:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 64))
-		return any(value).(T)
-	case uint:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case uint8:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 8))
-		return any(value).(T)
-	case uint16:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 16))
-		return any(value).(T)
-	case uint32:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 32))
-		return any(value).(T)
-	case uint64:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case uintptr:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case float32:
-		value := mylog.Check2(strconv.ParseFloat(str, 32))
-		return any(value).(T)
-	case float64:
-		value := mylog.Check2(strconv.ParseFloat(str, 64))
-		return any(value).(T)
-	case string:
-		return any(str).(T)
-
-		//todo 处理cpp服务端的字段返回 0x12345678 这种格式，我估计json会解码失败
-	case moduleInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleSectionInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleSectionInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleExport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleExport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleImport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleImport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case memoryBase:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassemblerAddress:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassembleRip:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassembleRipWithSetupIn:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case assemblerResult:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case void:
-		return any(nil).(T)
-
-	}
-	panic("not support type")
-}
-
-
 `
 
 	enum = `
@@ -553,6 +401,7 @@ const (
 
 	getSet = `
 func (m RegisterManager) Get(reg RegisterEnum) uint {
+	mylog.Check(m.checkKnown(reg))
 	switch reg {
 	case DR0:
 		return uint(m.GetDR0())
@@ -732,6 +581,7 @@ func (m RegisterManager) Get(reg RegisterEnum) uint {
 }
 
 func (m RegisterManager) Set(reg RegisterEnum, value uint) bool {
+	mylog.Check(m.checkKnown(reg))
 	switch reg {
 	case DR0:
 		return m.SetDR0(uint(value))
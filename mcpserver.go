@@ -0,0 +1,955 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// mcp server: JSON-RPC 2.0 over stdio, one message per line.
+// Every x64dbg facade method is exposed as a tool, see mcpTools.
+
+const (
+	mcpServerName      = "x64dbg-mcp"
+	mcpServerVersion   = "0.1.0"
+	mcpProtocolVersion = "2025-06-18"
+)
+
+var mcpProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18"}
+
+const (
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+)
+
+type (
+	jsonrpcRequest struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id,omitempty"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}
+	jsonrpcResponse struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  any             `json:"result,omitempty"`
+		Error   *jsonrpcError   `json:"error,omitempty"`
+	}
+	jsonrpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+type jsonSchema struct {
+	Type        string                `json:"type"`
+	Description string                `json:"description,omitempty"`
+	Properties  map[string]jsonSchema `json:"properties,omitempty"`
+	Required    []string              `json:"required,omitempty"`
+	Enum        []string              `json:"enum,omitempty"`
+}
+
+type (
+	mcpTool struct {
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		InputSchema jsonSchema `json:"inputSchema"`
+		call        func(ctx context.Context, x x64dbg, a toolArgs) any
+	}
+	toolParam struct {
+		name        string
+		typ         string
+		description string
+		enum        []string
+	}
+	toolContent struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	toolResult struct {
+		Content []toolContent `json:"content"`
+		IsError bool          `json:"isError"`
+	}
+)
+
+func newTool(name, description string, call func(ctx context.Context, x x64dbg, a toolArgs) any, params ...toolParam) mcpTool {
+	schema := jsonSchema{Type: "object", Properties: map[string]jsonSchema{}}
+	for _, p := range params {
+		schema.Properties[p.name] = jsonSchema{Type: p.typ, Description: p.description, Enum: p.enum}
+		schema.Required = append(schema.Required, p.name)
+	}
+	return mcpTool{Name: name, Description: description, InputSchema: schema, call: call}
+}
+
+func addressParam(name string) toolParam {
+	return toolParam{name: name, typ: "string", description: "address, hex like 0x401000 or decimal"}
+}
+
+func stringParam(name, description string) toolParam {
+	return toolParam{name: name, typ: "string", description: description}
+}
+
+func integerParam(name, description string) toolParam {
+	return toolParam{name: name, typ: "integer", description: description}
+}
+
+func booleanParam(name, description string) toolParam {
+	return toolParam{name: name, typ: "boolean", description: description}
+}
+
+// enumParam takes one of names, the empty placeholders of 1 based tables are left out of the schema.
+func enumParam(name, description string, names []string) toolParam {
+	var enum []string
+	for _, n := range names {
+		if n != "" {
+			enum = append(enum, n)
+		}
+	}
+	return toolParam{name: name, typ: "string", description: description, enum: enum}
+}
+
+func hexParam(name, description string) toolParam {
+	return toolParam{name: name, typ: "string", description: description + ", hex encoded like 9090c3"}
+}
+
+// toolArgs holds tools/call arguments, the getters panic on bad input like the facade does,
+// callTool turns that panic into an isError result.
+type toolArgs map[string]json.RawMessage
+
+func (a toolArgs) raw(name string) json.RawMessage {
+	v, ok := a[name]
+	if !ok {
+		panic("missing argument " + strconv.Quote(name))
+	}
+	return v
+}
+
+func (a toolArgs) String(name string) string {
+	var s string
+	if err := json.Unmarshal(a.raw(name), &s); err != nil {
+		panic("argument " + strconv.Quote(name) + " must be a string")
+	}
+	return s
+}
+
+// Uint accepts a JSON number or a string in hex (0x prefix) or decimal.
+func (a toolArgs) Uint(name string) uint64 {
+	raw := a.raw(name)
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	v, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		panic("argument " + strconv.Quote(name) + " is not a valid number: " + s)
+	}
+	return v
+}
+
+func (a toolArgs) Int(name string) int { return int(a.Uint(name)) }
+
+func (a toolArgs) Bool(name string) bool {
+	var b bool
+	if err := json.Unmarshal(a.raw(name), &b); err != nil {
+		panic("argument " + strconv.Quote(name) + " must be a boolean")
+	}
+	return b
+}
+
+func (a toolArgs) Hex(name string) HexBytes {
+	s := strings.ReplaceAll(a.String(name), " ", "")
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		panic("argument " + strconv.Quote(name) + " is not valid hex: " + err.Error())
+	}
+	return b
+}
+
+// Enum returns the index of the argument in names.
+func (a toolArgs) Enum(name string, names []string) int {
+	s := a.String(name)
+	for i, n := range names {
+		if n != "" && strings.EqualFold(n, s) {
+			return i
+		}
+	}
+	panic("argument " + strconv.Quote(name) + " must be one of " + strings.Join(names, ", "))
+}
+
+func (a toolArgs) Register(name string) RegisterEnum {
+	s := a.String(name)
+	reg, ok := RegisterEnumByName(s)
+	if !ok {
+		panic("unknown register " + strconv.Quote(s))
+	}
+	return reg
+}
+
+type mcpServer struct {
+	x     x64dbg
+	tools []mcpTool
+	index map[string]int
+
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc // tools/call 还在跑的，按 JSON-RPC id
+}
+
+func newMcpServer(x x64dbg) *mcpServer {
+	s := &mcpServer{x: x, tools: mcpTools(), index: map[string]int{}, calls: map[string]context.CancelFunc{}}
+	for i, t := range s.tools {
+		s.index[t.Name] = i
+	}
+	return s
+}
+
+// Serve reads requests from in until EOF and writes responses to out. Every tools/call runs on its own
+// with a context notifications/cancelled cancels, so a long call neither blocks the requests behind it
+// nor keeps running once the client gave up on it. At EOF Serve answers the calls still running first.
+func (s *mcpServer) Serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	enc := json.NewEncoder(out)
+	var (
+		writeMu  sync.Mutex
+		writeErr error
+		wg       sync.WaitGroup
+	)
+	write := func(resp jsonrpcResponse) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if writeErr == nil {
+			writeErr = enc.Encode(resp)
+		}
+		return writeErr
+	}
+	defer func() {
+		s.cancelAll()
+		wg.Wait()
+	}()
+	for scanner.Scan() {
+		line := []byte(strings.TrimSpace(scanner.Text()))
+		if len(line) == 0 {
+			continue
+		}
+		var req jsonrpcRequest
+		if json.Unmarshal(line, &req) == nil {
+			switch {
+			case req.Method == "notifications/cancelled":
+				s.cancel(req.Params)
+				continue
+			case req.Method == "tools/call" && req.ID != nil:
+				ctx, cancel := context.WithCancel(context.Background())
+				id := string(req.ID)
+				s.mu.Lock()
+				s.calls[id] = cancel
+				s.mu.Unlock()
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, _ := s.handle(ctx, line)
+					s.mu.Lock()
+					delete(s.calls, id)
+					s.mu.Unlock()
+					// 被取消的请求不再应答
+					if ctx.Err() == nil {
+						write(resp)
+					}
+					cancel()
+				}()
+				continue
+			}
+		}
+		resp, ok := s.handle(context.Background(), line)
+		if !ok {
+			continue
+		}
+		if err := write(resp); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	wg.Wait()
+	return writeErr
+}
+
+// cancel stops the tools/call params.requestId names, an unknown or finished one is ignored.
+func (s *mcpServer) cancel(params json.RawMessage) {
+	var p struct {
+		RequestID json.RawMessage `json:"requestId"`
+	}
+	if json.Unmarshal(params, &p) != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.calls[string(p.RequestID)]; ok {
+		cancel()
+	}
+}
+
+func (s *mcpServer) cancelAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cancel := range s.calls {
+		cancel()
+	}
+}
+
+// handle returns false for notifications, they never get a response. ctx is that of a tools/call.
+func (s *mcpServer) handle(ctx context.Context, line []byte) (jsonrpcResponse, bool) {
+	var req jsonrpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), jsonrpcParseError, err.Error()), true
+	}
+	if req.ID == nil {
+		return jsonrpcResponse{}, false
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, jsonrpcInvalidRequest, "invalid request"), true
+	}
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := mcpProtocolVersion
+		for _, v := range mcpProtocolVersions {
+			if v == params.ProtocolVersion {
+				version = v
+			}
+		}
+		return resultResponse(req.ID, map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": mcpServerName, "version": mcpServerVersion},
+		}), true
+	case "ping":
+		return resultResponse(req.ID, map[string]any{}), true
+	case "tools/list":
+		return resultResponse(req.ID, map[string]any{"tools": s.tools}), true
+	case "tools/call":
+		var params struct {
+			Name      string   `json:"name"`
+			Arguments toolArgs `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, jsonrpcInvalidParams, err.Error()), true
+		}
+		i, ok := s.index[params.Name]
+		if !ok {
+			return errorResponse(req.ID, jsonrpcInvalidParams, "unknown tool "+strconv.Quote(params.Name)), true
+		}
+		return resultResponse(req.ID, s.callTool(ctx, s.tools[i], params.Arguments)), true
+	}
+	return errorResponse(req.ID, jsonrpcMethodNotFound, "method not found: "+req.Method), true
+}
+
+// callTool hands the tool ctx and a facade whose requests stop with ctx, see Client.WithContext.
+func (s *mcpServer) callTool(ctx context.Context, t mcpTool, args toolArgs) (result toolResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = toolResult{Content: []toolContent{{Type: "text", Text: fmt.Sprint(r)}}, IsError: true}
+		}
+	}()
+	if args == nil {
+		args = toolArgs{}
+	}
+	x := s.x.Command.client.WithContext(ctx).X64dbg()
+	return toolResult{Content: []toolContent{{Type: "text", Text: toolText(t.call(ctx, x, args))}}}
+}
+
+func toolText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "ok"
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func resultResponse(id json.RawMessage, result any) jsonrpcResponse {
+	return jsonrpcResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) jsonrpcResponse {
+	return jsonrpcResponse{JSONRPC: "2.0", ID: id, Error: &jsonrpcError{Code: code, Message: message}}
+}
+
+func mcpTools() []mcpTool {
+	moduleName := stringParam("name", "module name, for example kernel32.dll")
+	return []mcpTool{
+		newTool("Restart", "Restart x64dbg as administrator",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Restart(); return nil }),
+		newTool("FindAsm", "Find an instruction starting at address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				size, data := x.FindAsm(a.Int("addr"), a.String("instruction"))
+				return assemblerResult{Size: size, Data: data}
+			},
+			addressParam("addr"), stringParam("instruction", "instruction text, for example mov eax, 1")),
+
+		newTool("CommandExec", "Execute an x64dbg command and return its log output",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Command.Exec(a.String("cmd")) },
+			stringParam("cmd", "command line, for example bp kernel32.CreateFileW")),
+
+		newTool("RegisterGet", "Read a register",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return HexInt(x.Register.Get(a.Register("register")))
+			},
+			stringParam("register", "register name, for example RAX, EIP, CFLAGS")),
+		newTool("RegisterSet", "Write a register",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Register.Set(a.Register("register"), uint(a.Uint("value")))
+			},
+			stringParam("register", "register name, for example RAX, EIP, CFLAGS"), addressParam("value")),
+		newTool("RegisterPtr", "Read a pointer sized register by a name that works under x64dbg and x32dbg",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Register.Ptr(a.String("register")) },
+			stringParam("register", "CIP, CSP, CBP, CAX and so on, or RSP under x64dbg and ESP under x32dbg")),
+		newTool("RegisterSnapshot", "Read every register at once: general purpose, flags, segments, debug, x87, MXCSR, XMM, YMM and, with AVX-512, ZMM and K",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Register.Snapshot().Registers() }),
+		newTool("RegisterGetXMM", "Read an XMM register with its float32x4 and float64x2 views",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				// 任意位模式都可能是 NaN，JSON 里放不下，浮点视图按文本给
+				v := x.Register.GetXMM(a.Int("n"))
+				return struct {
+					Value     RegisterBytes `json:"value"`
+					Float32x4 string        `json:"float32x4"`
+					Float64x2 string        `json:"float64x2"`
+				}{v[:], fmt.Sprint(v.Float32x4()), fmt.Sprint(v.Float64x2())}
+			},
+			integerParam("n", "register number, 0 to 31")),
+		newTool("RegisterSetXMM", "Write an XMM register",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				var v Vector128
+				copy(v[:], a.Hex("value"))
+				x.Register.SetXMM(a.Int("n"), v)
+				return nil
+			},
+			integerParam("n", "register number, 0 to 31"), hexParam("value", "16 bytes, lowest byte first")),
+		newTool("RegisterGetYMM", "Read a YMM register",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				v := x.Register.GetYMM(a.Int("n"))
+				return RegisterBytes(v[:])
+			},
+			integerParam("n", "register number, 0 to 31")),
+		newTool("RegisterGetST", "Read the x87 register ST(n) as a number",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Register.GetST(a.Int("n")).String() },
+			integerParam("n", "stack position, 0 to 7")),
+
+		newTool("MemoryRead", "Read debuggee memory, returns hex",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Memory.Read(a.Int("addr"), uint(a.Uint("size")))
+			},
+			addressParam("addr"), integerParam("size", "number of bytes")),
+		newTool("MemoryReadPtr", "Read a pointer of the debuggee's width",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Memory.ReadPtr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("MemoryReadString", "Read a NUL terminated string",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				if a.Enum("encoding", stringEncodingList) == 1 {
+					return x.Memory.ReadWString(a.Int("addr"), a.Int("max"))
+				}
+				return x.Memory.ReadCString(a.Int("addr"), a.Int("max"))
+			},
+			addressParam("addr"), enumParam("encoding", "ascii for char strings, utf16 for wchar_t strings", stringEncodingList),
+			integerParam("max", "maximum length in characters")),
+		newTool("MemoryWrite", "Write debuggee memory",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Memory.Write(a.Int("addr"), a.Hex("data"))
+			},
+			addressParam("addr"), hexParam("data", "bytes to write")),
+		newTool("MemoryIsValidPtr", "Check whether an address is readable",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Memory.IsValidPtr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("MemoryGetProtectFlag", "Get the page protection of an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Memory.GetProtectFlag(a.Int("addr")).String()
+			},
+			addressParam("addr")),
+		newTool("MemoryMap", "List every region of the address space: base, size, state, type, protection and owner",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				var b strings.Builder
+				for _, r := range x.Memory.Map() {
+					fmt.Fprintf(&b, "0x%x 0x%x %v %v %v %s\n", r.BaseAddress, r.Size, r.State, r.Type, r.Protect, r.Info)
+				}
+				return b.String()
+			}),
+		newTool("MemoryAlloc", "Allocate memory in the debuggee, returns its address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return HexInt(x.Memory.Alloc(a.Int("size"), pageRightsList[a.Enum("protect", pageRightsToolNames)]))
+			},
+			addressParam("size"), enumParam("protect", "page protection", pageRightsToolNames)),
+		newTool("MemoryFree", "Free memory allocated with MemoryAlloc",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Memory.Free(a.Int("addr")); return nil },
+			addressParam("addr")),
+		newTool("MemoryFill", "Set a range of debuggee memory to one byte value",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Memory.Fill(a.Int("addr"), a.Int("size"), byte(a.Uint("value")))
+				return nil
+			},
+			addressParam("addr"), addressParam("size"), integerParam("value", "byte value 0-255")),
+		newTool("MemoryCopy", "Copy memory inside the debuggee",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Memory.Copy(a.Int("dst"), a.Int("src"), a.Int("size"))
+				return nil
+			},
+			addressParam("dst"), addressParam("src"), addressParam("size")),
+		newTool("MemorySetProtection", "Change the page protection of a range",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				protect := pageRightsList[a.Enum("protect", pageRightsToolNames)]
+				if a.Bool("guard") {
+					protect |= PageGuard
+				}
+				x.Memory.SetProtection(a.Int("addr"), a.Int("size"), protect)
+				return nil
+			},
+			addressParam("addr"), addressParam("size"), enumParam("protect", "page protection", pageRightsToolNames),
+			booleanParam("guard", "add PAGE_GUARD")),
+		newTool("MemorySaveToFile", "Save a range of debuggee memory to a file on the debugger's machine",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Memory.SaveToFile(a.String("path"), a.Int("addr"), a.Int("size"))
+				return nil
+			},
+			stringParam("path", "output file path"), addressParam("addr"), addressParam("size")),
+		newTool("MemoryFindBaseByAddress", "Find the allocation base and size of an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Memory.FindBaseByAddress(a.Int("addr")) },
+			addressParam("addr")),
+
+		newTool("DebugActive", "Check whether the debuggee is running",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Debug.Active() }),
+		newTool("DebugDebugging", "Check whether a process is being debugged",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Debug.Debugging() }),
+		newTool("DebugRun", "Resume execution",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.Run(); return nil }),
+		newTool("DebugPause", "Pause execution",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.Pause(); return nil }),
+		newTool("DebugStop", "Stop debugging",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.Stop(); return nil }),
+		newTool("DebugStepIn", "Step into",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.StepIn(); return nil }),
+		newTool("DebugStepOver", "Step over",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.StepOver(); return nil }),
+		newTool("DebugStepOut", "Step out",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Debug.StepOut(); return nil }),
+		newTool("DebugSetBreakpoint", "Set a software breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Debug.SetBreakpoint(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("DebugDeleteBreakpoint", "Delete a software breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Debug.DeleteBreakpoint(a.Int("addr")) },
+			addressParam("addr")),
+
+		newTool("BreakpointList", "List all breakpoints with kind, state, hit count and conditions",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Breakpoints.List(BreakpointAll) }),
+		newTool("BreakpointSetHardware", "Set a hardware breakpoint, execute needs size byte",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetHardware(a.Int("addr"), HardwareType(a.Enum("type", hardwareTypeNames[:])), HardwareSize(a.Enum("size", hardwareSizeNames[:])))
+			},
+			addressParam("addr"), enumParam("type", "access type", hardwareTypeNames[:]), enumParam("size", "watched size", hardwareSizeNames[:])),
+		newTool("BreakpointSetMemory", "Set a memory breakpoint on the page of an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetMemory(a.Int("addr"), MemoryType(a.Enum("type", memoryTypeNames[:])), a.Bool("singleshot"))
+			},
+			addressParam("addr"), enumParam("type", "access type", memoryTypeNames[:]), booleanParam("singleshot", "remove after the first hit")),
+		newTool("BreakpointSetMemoryRange", "Set a memory breakpoint on an address range",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetMemoryRange(a.Int("addr"), a.Int("size"), MemoryType(a.Enum("type", memoryTypeNames[:])))
+			},
+			addressParam("addr"), integerParam("size", "range size in bytes"), enumParam("type", "access type", memoryTypeNames[:])),
+		newTool("BreakpointSetDll", "Break when a DLL loads or unloads",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetDll(a.String("name"), DllType(a.Enum("type", dllTypeNames[:])))
+			},
+			moduleName, enumParam("type", "event", dllTypeNames[:])),
+		newTool("BreakpointSetException", "Break on an exception code, for example 0xC0000005",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetException(uint32(a.Uint("code")), ExceptionChance(a.Enum("chance", exceptionChanceNames[:])))
+			},
+			addressParam("code"), enumParam("chance", "first or second chance", exceptionChanceNames[:])),
+		newTool("BreakpointEnable", "Enable a breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Breakpoints.Enable(breakpointArg(a))
+				return nil
+			},
+			breakpointKindParam, breakpointTargetParam),
+		newTool("BreakpointDisable", "Disable a breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Breakpoints.Disable(breakpointArg(a))
+				return nil
+			},
+			breakpointKindParam, breakpointTargetParam),
+		newTool("BreakpointDelete", "Delete a breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Breakpoints.Delete(breakpointArg(a))
+				return nil
+			},
+			breakpointKindParam, breakpointTargetParam),
+		newTool("BreakpointGet", "Read a breakpoint back with its options and hit count",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Breakpoints.Get(breakpointArg(a)) },
+			breakpointKindParam, breakpointTargetParam),
+		newTool("BreakpointSetOptions", "Replace all options of a breakpoint at once, empty strings clear a setting",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Breakpoints.SetOptions(breakpointArg(a), BreakpointOptions{
+					Name:             a.String("name"),
+					Condition:        a.String("condition"),
+					Log:              a.String("log"),
+					LogCondition:     a.String("logCondition"),
+					Command:          a.String("command"),
+					CommandCondition: a.String("commandCondition"),
+					LogFile:          a.String("logFile"),
+					FastResume:       a.Bool("fastResume"),
+					Singleshot:       a.Bool("singleshot"),
+					Silent:           a.Bool("silent"),
+				})
+			},
+			breakpointKindParam, breakpointTargetParam,
+			stringParam("name", "breakpoint name"),
+			stringParam("condition", "break condition expression, for example rcx==0"),
+			stringParam("log", "log text, for example rcx={rcx}"),
+			stringParam("logCondition", "condition for logging"),
+			stringParam("command", "command to run on hit"),
+			stringParam("commandCondition", "condition for running the command"),
+			stringParam("logFile", "file the log text is written to"),
+			booleanParam("fastResume", "skip the GUI update when not breaking"),
+			booleanParam("singleshot", "delete the breakpoint after the first hit"),
+			booleanParam("silent", "do not print the default breakpoint log")),
+		newTool("BreakpointResetHitCount", "Reset the hit count of a breakpoint",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Breakpoints.ResetHitCount(breakpointArg(a))
+				return nil
+			},
+			breakpointKindParam, breakpointTargetParam),
+
+		newTool("AssemblerAssemble", "Assemble an instruction without writing it",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Assembler.Assemble(a.Int("addr"), a.String("instruction"))
+			},
+			addressParam("addr"), stringParam("instruction", "instruction text")),
+		newTool("AssemblerAssembleMem", "Assemble an instruction and write it to memory",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Assembler.AssembleMem(a.Int("addr"), a.Hex("opcodes"))
+			},
+			addressParam("addr"), hexParam("opcodes", "instruction bytes")),
+
+		newTool("StackPop", "Pop a value from the stack",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Stack.Pop() }),
+		newTool("StackPush", "Push a value to the stack",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Stack.Push(uint(a.Uint("value"))) },
+			addressParam("value")),
+		newTool("StackPeek", "Read a stack slot",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Stack.Peek(a.Int("offset")) },
+			integerParam("offset", "slot offset from the stack pointer")),
+		newTool("StackCallStack", "Walk the call stack of a thread: frame, code address, return address, symbol and comment",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Stack.CallStack(a.Int("id")) },
+			stackThreadParam),
+		newTool("StackDump", "Dump the stack of a thread slot by slot with the symbol each value points to",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Stack.Dump(a.Int("id"), a.Int("count")) },
+			stackThreadParam, integerParam("count", "number of pointer sized slots, for example 32")),
+
+		newTool("DisassemblerAtAddress", "Disassemble one instruction",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Disassembler.AtAddress(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("DisassemblerAtAddressWithSize", "Disassemble count instructions",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Disassembler.AtAddressWithSize(a.Int("addr"), a.Int("count"))
+			},
+			addressParam("addr"), integerParam("count", "number of instructions, 1 to 100")),
+		newTool("DisassemblerAtRip", "Disassemble the instruction at the instruction pointer",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Disassembler.AtRip() }),
+		newTool("DisassemblerAtRipFromStepIn", "Step into and disassemble the new instruction",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Disassembler.AtRipFromStepIn() }),
+
+		newTool("FlagGet", "Read a cpu flag",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Flag.Get(a.String("flag")) },
+			stringParam("flag", "ZF, OF, CF, PF, SF, TF, AF, DF or IF")),
+		newTool("FlagSet", "Write a cpu flag",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Flag.Set(a.String("flag"), a.Bool("value"))
+			},
+			stringParam("flag", "ZF, OF, CF, PF, SF, TF, AF, DF or IF"), booleanParam("value", "flag value")),
+		newTool("FlagAll", "Read every cpu flag at once, as x64dbg's flags panel shows them",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return flagsResult(x.Flag.Flags()) }),
+		newTool("FlagUpdate", "Set and clear several cpu flags in a single write",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				set := must(ParseFlags(a.String("set")))
+				clear := must(ParseFlags(a.String("clear")))
+				return flagsResult(x.Flag.Update(set, clear))
+			},
+			stringParam("set", "flags to set, for example ZF,CF"), stringParam("clear", "flags to clear, for example OF,SF")),
+
+		newTool("PatternFindMemory", "Find the first match of a byte pattern",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Pattern.FindMemory(a.Int("start"), a.Int("size"), a.String("pattern"))
+			},
+			addressParam("start"), integerParam("size", "number of bytes to scan"),
+			stringParam("pattern", "byte pattern, for example 48 8B ?? 05")),
+		newTool("PatternFindAll", "Find every match of an IDA style signature in a range",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Pattern.FindAll(ScanRange(a.Int("start"), a.Int("size")), a.String("pattern"), a.Int("max"))
+			},
+			addressParam("start"), addressParam("size"), signatureParam, maxFindResultParam),
+		newTool("PatternFindAllInModule", "Find every match of an IDA style signature in a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Pattern.FindAll(ScanModule(ModuleNamed(a.String("module"))), a.String("pattern"), a.Int("max"))
+			},
+			stringParam("module", "module name, for example kernel32.dll"), signatureParam, maxFindResultParam),
+		newTool("PatternFindAllInSection", "Find every match of an IDA style signature in one section of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				scope := ScanSection(ModuleNamed(a.String("module")), a.String("section"))
+				return x.Pattern.FindAll(scope, a.String("pattern"), a.Int("max"))
+			},
+			stringParam("module", "module name, for example kernel32.dll"), stringParam("section", "section name, for example .text"),
+			signatureParam, maxFindResultParam),
+		newTool("PatternFindAllInMemory", "Find every match of an IDA style signature in all committed readable memory",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Pattern.FindAll(ScanMemoryMap(), a.String("pattern"), a.Int("max"))
+			},
+			signatureParam, maxFindResultParam),
+		newTool("PatternScanSignatures", "Scan a module for several IDA style signatures at once, in one pass over its image read by the MCP server",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				s := must(ParseSignatures(strings.Split(strings.TrimSpace(a.String("patterns")), "\n")...))
+				type hit struct {
+					Address HexInt `json:"address"`
+					Pattern string `json:"pattern"`
+				}
+				hits := []hit{}
+				for m, err := range x.Pattern.ScanLocal(ctx, ScanModule(ModuleNamed(a.String("module"))), s) {
+					mylog.Check(err)
+					hits = append(hits, hit{HexInt(m.Offset), s.Patterns()[m.Index].String()})
+				}
+				return hits
+			},
+			stringParam("module", "module name, for example kernel32.dll"), stringParam("patterns", "IDA style signatures, one per line")),
+
+		newTool("XrefToAddress", "List the instructions referring to an address: who calls or reads it",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Xref.ToAddress(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("XrefStrings", "List the string references of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Xref.Strings(ModuleNamed(a.String("module")))
+			},
+			stringParam("module", "module name, for example kernel32.dll")),
+		newTool("XrefCalls", "List the intermodular calls of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Xref.Calls(ModuleNamed(a.String("module")))
+			},
+			stringParam("module", "module name, for example kernel32.dll")),
+
+		newTool("SymbolFromAddress", "Find the symbol at or before an address: module, name, displacement",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Symbol.FromAddress(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("SymbolResolve", "Find a symbol by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Symbol.Resolve(a.String("name")) },
+			stringParam("name", "symbol name, for example kernel32!CreateFileW")),
+		newTool("SymbolList", "List the symbols of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Symbol.List(a.String("module")) },
+			stringParam("module", "module name, for example kernel32.dll")),
+		newTool("SymbolLoad", "Load a pdb file as the symbols of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Symbol.Load(a.String("module"), a.String("path"), a.Bool("force"))
+				return nil
+			},
+			stringParam("module", "module name, for example kernel32.dll"), stringParam("path", "pdb file path"),
+			booleanParam("force", "skip the pdb signature check")),
+		newTool("SymbolUnload", "Unload the symbols of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Symbol.Unload(a.String("module")); return nil },
+			stringParam("module", "module name, for example kernel32.dll")),
+		newTool("SymbolDownload", "Download the pdb of a module from the symbol server",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Symbol.Download(a.String("module")); return nil },
+			stringParam("module", "module name, empty for every loaded module")),
+		newTool("SymbolSetStorePath", "Set the local symbol store pdbs are downloaded to",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Symbol.SetStorePath(a.String("path"))
+				return nil
+			},
+			stringParam("path", "directory, for example C:\\symbols")),
+
+		newTool("MiscParseExpression", "Evaluate an x64dbg expression",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return HexInt(x.Misc.ParseExpression(a.String("expression")))
+			},
+			stringParam("expression", "expression, for example [rsp+8]")),
+		newTool("MiscGetApiAddressFromModule", "Resolve an exported function address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Misc.GetApiAddressFromModule(a.String("module"), a.String("api"))
+			},
+			stringParam("module", "module name"), stringParam("api", "export name")),
+
+		newTool("ModuleInfoFromAddr", "Module info for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.InfoFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModuleInfoFromName", "Module info by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.InfoFromName(a.String("name")) },
+			moduleName),
+		newTool("ModuleBaseFromAddr", "Module base for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.BaseFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModuleBaseFromName", "Module base by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.BaseFromName(a.String("name")) },
+			moduleName),
+		newTool("ModuleSizeFromAddr", "Module size for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.SizeFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModuleSizeFromName", "Module size by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.SizeFromName(a.String("name")) },
+			moduleName),
+		newTool("ModuleNameFromAddr", "Module name for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.NameFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModulePathFromAddr", "Module path for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.PathFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModulePathFromName", "Module path by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.PathFromName(a.String("name")) },
+			moduleName),
+		newTool("ModuleEntryFromAddr", "Module entry point for an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.EntryFromAddr(a.Int("addr")) },
+			addressParam("addr")),
+		newTool("ModuleEntryFromName", "Module entry point by name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.EntryFromName(a.String("name")) },
+			moduleName),
+		newTool("ModuleSectionCountFromAddr", "Section count of the module containing an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionCountFromAddr(a.Int("addr"))
+			},
+			addressParam("addr")),
+		newTool("ModuleSectionCountFromName", "Section count of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionCountFromName(a.String("name"))
+			},
+			moduleName),
+		newTool("ModuleSectionFromAddr", "One section of the module containing an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionFromAddr(a.Int("addr"), a.Int("number"))
+			},
+			addressParam("addr"), integerParam("number", "section index")),
+		newTool("ModuleSectionFromName", "One section of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionFromName(a.String("name"), a.Int("number"))
+			},
+			moduleName, integerParam("number", "section index")),
+		newTool("ModuleSectionListFromAddr", "Sections of the module containing an address",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionListFromAddr(a.Int("addr"))
+			},
+			addressParam("addr")),
+		newTool("ModuleSectionListFromName", "Sections of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.SectionListFromName(a.String("name"))
+			},
+			moduleName),
+		newTool("ModuleGetMainModuleInfo", "Main module info",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleInfo() }),
+		newTool("ModuleGetMainModuleBase", "Main module base",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleBase() }),
+		newTool("ModuleGetMainModuleSize", "Main module size",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleSize() }),
+		newTool("ModuleGetMainModuleEntry", "Main module entry point",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleEntry() }),
+		newTool("ModuleGetMainModuleSectionCount", "Main module section count",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleSectionCount() }),
+		newTool("ModuleGetMainModuleName", "Main module name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleName() }),
+		newTool("ModuleGetMainModulePath", "Main module path",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModulePath() }),
+		newTool("ModuleGetMainModuleSectionList", "Main module sections",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetMainModuleSectionList() }),
+		newTool("ModuleGetList", "List loaded modules",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Module.GetList() }),
+		newTool("ModuleGetExports", "Export table of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.Exports(ModuleNamed(a.String("name")))
+			},
+			moduleName),
+		newTool("ModuleGetImports", "Import table of a module",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return x.Module.Imports(ModuleNamed(a.String("name")))
+			},
+			moduleName),
+
+		newTool("ThreadList", "List the debuggee's threads with id, TEB, start address, CIP, priority, suspend count and name",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { return x.Thread.List() }),
+		newTool("ThreadSwitch", "Make a thread the current thread of x64dbg",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Thread.Switch(a.Int("id")); return nil },
+			threadIDParam),
+		newTool("ThreadSuspend", "Suspend a thread",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Thread.Suspend(a.Int("id")); return nil },
+			threadIDParam),
+		newTool("ThreadResume", "Resume a thread",
+			func(ctx context.Context, x x64dbg, a toolArgs) any { x.Thread.Resume(a.Int("id")); return nil },
+			threadIDParam),
+		newTool("ThreadKill", "Terminate a thread",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Thread.Kill(a.Int("id"), uint32(a.Uint("exitCode")))
+				return nil
+			},
+			threadIDParam, integerParam("exitCode", "exit code of the thread")),
+		newTool("ThreadSetPriority", "Set the priority of a thread",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Thread.SetPriority(a.Int("id"), threadPriorityList[a.Enum("priority", threadPriorityToolNames)])
+				return nil
+			},
+			threadIDParam, enumParam("priority", "thread priority", threadPriorityToolNames)),
+		newTool("ThreadSetName", "Name a thread",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				x.Thread.SetName(a.Int("id"), a.String("name"))
+				return nil
+			},
+			threadIDParam, stringParam("name", "thread name")),
+		newTool("ThreadGetRegister", "Read a register in the context of a thread without switching to it",
+			func(ctx context.Context, x x64dbg, a toolArgs) any {
+				return HexInt(x.Thread.GetRegister(a.Int("id"), a.Register("register")))
+			},
+			threadIDParam, stringParam("register", "register name, for example RAX, EIP, CFLAGS")),
+	}
+}
+
+var (
+	breakpointKindList    = []string{"normal", "hardware", "memory", "dll", "exception"}
+	breakpointKindParam   = enumParam("kind", "breakpoint kind", breakpointKindList)
+	breakpointTargetParam = stringParam("target", "address, exception code, or the module name of a dll breakpoint")
+
+	pageRightsList      = []PageProtection{PageNoAccess, PageReadOnly, PageReadWrite, PageWriteCopy, PageExecute, PageExecuteRead, PageExecuteReadWrite, PageExecuteWriteCopy}
+	pageRightsToolNames = []string{"NoAccess", "ReadOnly", "ReadWrite", "WriteCopy", "Execute", "ExecuteRead", "ExecuteReadWrite", "ExecuteWriteCopy"}
+	stringEncodingList  = []string{"ascii", "utf16"}
+
+	signatureParam     = stringParam("pattern", "IDA style signature, ?? for a wildcard byte and 4? for a nibble, for example 48 8B 05 ?? ?? ?? ??")
+	maxFindResultParam = integerParam("max", "stop after this many matches, 0 for the default of 5000")
+
+	threadIDParam           = integerParam("id", "thread id as shown by ThreadList")
+	stackThreadParam        = integerParam("id", "thread id as shown by ThreadList, 0 for the current thread")
+	threadPriorityList      = []ThreadPriority{ThreadPriorityIdle, ThreadPriorityLowest, ThreadPriorityBelowNormal, ThreadPriorityNormal, ThreadPriorityAboveNormal, ThreadPriorityHighest, ThreadPriorityTimeCritical}
+	threadPriorityToolNames = []string{"Idle", "Lowest", "BelowNormal", "Normal", "AboveNormal", "Highest", "TimeCritical"}
+)
+
+// breakpointArg builds the Breakpoint the breakpoint tools work on from kind and target.
+func breakpointArg(a toolArgs) Breakpoint {
+	bp := Breakpoint{Kind: BreakpointKind(1 << a.Enum("kind", breakpointKindList))}
+	if bp.Kind == BreakpointDll {
+		bp.Module = a.String("target")
+	} else {
+		bp.Address = HexInt(a.Uint("target"))
+	}
+	return bp
+}
+
+// flagsResult is the value of CFLAGS and the flags panel text of FlagAll and FlagUpdate.
+func flagsResult(f Flags) any {
+	return struct {
+		CFLAGS HexInt `json:"cflags"`
+		Flags  string `json:"flags"`
+	}{HexInt(f), f.String()}
+}
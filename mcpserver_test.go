@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePlugin answers like MCPx64dbg.cpp, routes map "/path" to a canned body,
+// a route missing from the map gets the plugin's 404.
+func fakePlugin(t *testing.T, routes map[string]string) *Client {
+	t.Helper()
+	p := &fakeServer{}
+	for path, body := range routes {
+		p.handle(path, func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) })
+	}
+	return p.client(t)
+}
+
+// fakeServer is fakePlugin with handlers for the routes that need more than a canned body, and it
+// keeps every request it got in order.
+type fakeServer struct {
+	mu       sync.Mutex
+	routes   map[string]http.HandlerFunc
+	requests []fakeRequest
+}
+
+// fakeRequest is one request fakeServer got, Body already read.
+type fakeRequest struct {
+	Method, Path string
+	Query        url.Values
+	Body         string
+}
+
+// handle routes path to h, it can replace a route while the server runs.
+func (p *fakeServer) handle(path string, h http.HandlerFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.routes == nil {
+		p.routes = map[string]http.HandlerFunc{}
+	}
+	p.routes[path] = h
+}
+
+func (p *fakeServer) client(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		p.mu.Lock()
+		p.requests = append(p.requests, fakeRequest{r.Method, r.URL.Path, r.URL.Query(), string(body)})
+		h, ok := p.routes[r.URL.Path]
+		p.mu.Unlock()
+		if !ok {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL)
+}
+
+// sent lists the requests as format writes them, those format returns "" for are left out.
+func (p *fakeServer) sent(format func(r fakeRequest) string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var lines []string
+	for _, r := range p.requests {
+		if line := format(r); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// bodies lists what was posted to path, the commands for "/ExecCommand".
+func (p *fakeServer) bodies(path string) []string {
+	return p.sent(func(r fakeRequest) string {
+		if r.Path != path {
+			return ""
+		}
+		return r.Body
+	})
+}
+
+func (p *fakeServer) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = nil
+}
+
+func mcpSession(t *testing.T, x x64dbg, requests ...string) []jsonrpcResponse {
+	t.Helper()
+	var out bytes.Buffer
+	if err := newMcpServer(x).Serve(strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+	var responses []jsonrpcResponse
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp jsonrpcResponse
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func toolCall(name string, args map[string]any) string {
+	b, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	return string(b)
+}
+
+func callResult(t *testing.T, resp jsonrpcResponse) toolResult {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("unexpected error %+v", resp.Error)
+	}
+	b, _ := json.Marshal(resp.Result)
+	var r toolResult
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestMcpServerHandshake(t *testing.T) {
+	responses := mcpSession(t, x64dbg{},
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"no/such/method"}`,
+		`not json`,
+	)
+	if len(responses) != 4 {
+		t.Fatalf("got %d responses, want 4 (notification must not be answered)", len(responses))
+	}
+
+	init := responses[0].Result.(map[string]any)
+	if init["protocolVersion"] != "2024-11-05" {
+		t.Errorf("protocolVersion = %v", init["protocolVersion"])
+	}
+
+	tools := responses[1].Result.(map[string]any)["tools"].([]any)
+	if len(tools) != len(mcpTools()) {
+		t.Errorf("tools/list returned %d tools, want %d", len(tools), len(mcpTools()))
+	}
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		tool := tool.(map[string]any)
+		name := tool["name"].(string)
+		if seen[name] {
+			t.Errorf("duplicate tool %s", name)
+		}
+		seen[name] = true
+		schema := tool["inputSchema"].(map[string]any)
+		if schema["type"] != "object" {
+			t.Errorf("%s: inputSchema type = %v", name, schema["type"])
+		}
+	}
+
+	if responses[2].Error == nil || responses[2].Error.Code != jsonrpcMethodNotFound {
+		t.Errorf("unknown method: %+v", responses[2].Error)
+	}
+	if responses[3].Error == nil || responses[3].Error.Code != jsonrpcParseError {
+		t.Errorf("bad json: %+v", responses[3].Error)
+	}
+}
+
+func TestMcpServerToolsCall(t *testing.T) {
+	x := fakePlugin(t, map[string]string{
+		"/Memory/Read":     "4d5a9000",
+		"/Register/Get":    "0x401000",
+		"/Debug/Run":       "Debug run executed",
+		"/Memory/Write":    "Memory written successfully",
+		"/GetModuleList":   `[{"name":"a.exe","base":"0x400000","size":"0x1000","entry":"0x401000","sectionCount":3,"path":"C:\\a.exe"}]`,
+		"/MemoryBase":      `{"base_address":"0x400000","size":"0x1000"}`,
+		"/Is_Debugging":    "true",
+		"/Stack/Peek":      "0x7ff0",
+		"/Flag/Get":        "false",
+		"/Pattern/FindMem": "0x401234",
+	}).X64dbg()
+
+	cases := []struct {
+		tool string
+		args map[string]any
+		want string
+	}{
+		{"MemoryRead", map[string]any{"addr": "0x400000", "size": 4}, `"4d5a9000"`},
+		{"MemoryWrite", map[string]any{"addr": 4194304, "data": "90 90"}, "true"},
+		{"RegisterGet", map[string]any{"register": "rip"}, `"0x401000"`},
+		{"DebugRun", nil, "ok"},
+		{"DebugDebugging", nil, "true"},
+		{"StackPeek", map[string]any{"offset": 0}, `"0x7ff0"`},
+		{"FlagGet", map[string]any{"flag": "ZF"}, "false"},
+		{"PatternFindMemory", map[string]any{"start": "0x401000", "size": 4096, "pattern": "48 8B"}, `"0x401234"`},
+	}
+	for _, c := range cases {
+		t.Run(c.tool, func(t *testing.T) {
+			r := callResult(t, mcpSession(t, x, toolCall(c.tool, c.args))[0])
+			if r.IsError {
+				t.Fatalf("isError: %s", r.Content[0].Text)
+			}
+			if got := r.Content[0].Text; got != c.want {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMcpServerToolErrors(t *testing.T) {
+	x := fakePlugin(t, map[string]string{}).X64dbg()
+
+	for _, call := range []string{
+		toolCall("MemoryRead", map[string]any{"addr": "0x400000", "size": 4}), // plugin 404
+		toolCall("MemoryRead", map[string]any{"size": 4}),                     // missing argument
+		toolCall("RegisterGet", map[string]any{"register": "XYZ"}),            // unknown register
+	} {
+		r := callResult(t, mcpSession(t, x, call)[0])
+		if !r.IsError {
+			t.Errorf("%s: expected isError, got %s", call, r.Content[0].Text)
+		}
+	}
+
+	resp := mcpSession(t, x, toolCall("NoSuchTool", nil))[0]
+	if resp.Error == nil || resp.Error.Code != jsonrpcInvalidParams {
+		t.Errorf("unknown tool: %+v", resp.Error)
+	}
+}
+
+func TestMcpServerCancel(t *testing.T) {
+	started, cancelled := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Debug/Run":
+			close(started)
+			<-r.Context().Done()
+			close(cancelled)
+		case "/Register/Get":
+			w.Write([]byte("0x401000"))
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	in, feed := io.Pipe()
+	out, responses := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- newMcpServer(NewClient(srv.URL).X64dbg()).Serve(in, responses) }()
+	dec := json.NewDecoder(out)
+	send := func(line string) { io.WriteString(feed, line+"\n") }
+
+	send(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"DebugRun","arguments":{}}}`)
+	<-started
+	// 卡住的调用不挡后面的请求
+	send(`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"RegisterGet","arguments":{"register":"rip"}}}`)
+	var resp jsonrpcResponse
+	if err := dec.Decode(&resp); err != nil || string(resp.ID) != "8" {
+		t.Fatalf("first response %s, %v", resp.ID, err)
+	}
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user"}}`)
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("DebugRun was not cancelled")
+	}
+	send(`{"jsonrpc":"2.0","id":9,"method":"ping"}`)
+	if err := dec.Decode(&resp); err != nil || string(resp.ID) != "9" {
+		t.Errorf("cancelled call answered: %s, %v", resp.ID, err)
+	}
+	feed.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
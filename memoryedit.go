@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// the page rights setpagerights takes, a leading G adds PAGE_GUARD
+var pageRightsNames = map[PageProtection]string{
+	PageNoAccess:         "NoAccess",
+	PageReadOnly:         "ReadOnly",
+	PageReadWrite:        "ReadWrite",
+	PageWriteCopy:        "WriteCopy",
+	PageExecute:          "Execute",
+	PageExecuteRead:      "ExecuteRead",
+	PageExecuteReadWrite: "ExecuteReadWrite",
+	PageExecuteWriteCopy: "ExecuteWriteCopy",
+}
+
+func (p PageProtection) rights() (string, error) {
+	name, ok := pageRightsNames[p.Access()]
+	if !ok || p&^(pageAccessMask|PageGuard) != 0 {
+		return "", fmt.Errorf("x64dbg: setpagerights: unsupported protection %v", p)
+	}
+	if p.Guard() {
+		name = "G" + name
+	}
+	return name, nil
+}
+
+func (m memory) exec(ctx context.Context, format string, args ...any) error {
+	_, err := command{m.client}.ExecContext(ctx, fmt.Sprintf(format, args...))
+	return err
+}
+
+// Alloc commits size bytes in the debuggee and returns their address. x64dbg allocates
+// PAGE_EXECUTE_READWRITE, any other protect is applied afterwards, 0 keeps it.
+func (m memory) Alloc(size int, protect PageProtection) int {
+	return must(m.TryAlloc(size, protect))
+}
+func (m memory) TryAlloc(size int, protect PageProtection) (int, error) {
+	return m.AllocContext(context.Background(), size, protect)
+}
+func (m memory) AllocContext(ctx context.Context, size int, protect PageProtection) (int, error) {
+	if protect != 0 && protect != PageExecuteReadWrite {
+		// 先校验，免得分配成功了才发现改不了属性
+		if _, err := protect.rights(); err != nil {
+			return 0, err
+		}
+	}
+	address, err := tryRequest[HexInt](m.client, ctx, "Memory/Alloc", map[string]string{"size": strconv.Itoa(size)})
+	if err != nil {
+		return 0, err
+	}
+	if protect != 0 && protect != PageExecuteReadWrite {
+		if err := m.SetProtectionContext(ctx, int(address), size, protect); err != nil {
+			m.FreeContext(context.WithoutCancel(ctx), int(address))
+			return 0, err
+		}
+	}
+	return int(address), nil
+}
+
+// Free releases memory Alloc returned.
+func (m memory) Free(address int) { mylog.Check(m.TryFree(address)) }
+func (m memory) TryFree(address int) error {
+	return m.FreeContext(context.Background(), address)
+}
+func (m memory) FreeContext(ctx context.Context, address int) error {
+	return m.exec(ctx, "free 0x%x", address)
+}
+
+// Fill sets size bytes at address to value, the memset command.
+func (m memory) Fill(address int, size int, value byte) { mylog.Check(m.TryFill(address, size, value)) }
+func (m memory) TryFill(address int, size int, value byte) error {
+	return m.FillContext(context.Background(), address, size, value)
+}
+func (m memory) FillContext(ctx context.Context, address int, size int, value byte) error {
+	return m.exec(ctx, "memset 0x%x, 0x%x, 0x%x", address, value, size)
+}
+
+// Copy copies size bytes from src to dst inside the debuggee, the memcpy command.
+func (m memory) Copy(dst int, src int, size int) { mylog.Check(m.TryCopy(dst, src, size)) }
+func (m memory) TryCopy(dst int, src int, size int) error {
+	return m.CopyContext(context.Background(), dst, src, size)
+}
+func (m memory) CopyContext(ctx context.Context, dst int, src int, size int) error {
+	return m.exec(ctx, "memcpy 0x%x, 0x%x, 0x%x", dst, src, size)
+}
+
+// SetProtection changes the protection of every page in [address, address+size). protect is one access
+// value, optionally with PageGuard, setpagerights supports no other modifiers.
+func (m memory) SetProtection(address int, size int, protect PageProtection) {
+	mylog.Check(m.TrySetProtection(address, size, protect))
+}
+func (m memory) TrySetProtection(address int, size int, protect PageProtection) error {
+	return m.SetProtectionContext(context.Background(), address, size, protect)
+}
+func (m memory) SetProtectionContext(ctx context.Context, address int, size int, protect PageProtection) error {
+	rights, err := protect.rights()
+	if err != nil {
+		return err
+	}
+	// setpagerights 一次只改一页
+	for page := address &^ (memoryPageSize - 1); page < address+max(size, 1); page += memoryPageSize {
+		if err := m.exec(ctx, "setpagerights 0x%x, %s", page, rights); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveToFile writes size bytes at address to path on the debugger's machine, the savedata command.
+func (m memory) SaveToFile(path string, address int, size int) {
+	mylog.Check(m.TrySaveToFile(path, address, size))
+}
+func (m memory) TrySaveToFile(path string, address int, size int) error {
+	return m.SaveToFileContext(context.Background(), path, address, size)
+}
+func (m memory) SaveToFileContext(ctx context.Context, path string, address int, size int) error {
+	return m.exec(ctx, "savedata %s, 0x%x, 0x%x", strconv.Quote(path), address, size)
+}
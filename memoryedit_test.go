@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMemoryEdit(t *testing.T) {
+	var allocSize string
+	failRights := false
+	p := &fakeServer{}
+	p.handle("/Memory/Alloc", func(w http.ResponseWriter, r *http.Request) {
+		allocSize = r.URL.Query().Get("size")
+		w.Write([]byte("0x1f0000"))
+	})
+	p.handle("/ExecCommand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if failRights && strings.HasPrefix(string(body), "setpagerights 0x1f1000") {
+			http.Error(w, "Command execution failed", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("Command executed successfully (no output captured)"))
+	})
+	m := p.client(t).X64dbg().Memory
+
+	if got := m.Alloc(0x1000, 0); got != 0x1f0000 || allocSize != "4096" {
+		t.Errorf("Alloc = 0x%x, size %s", got, allocSize)
+	}
+	if got := m.Alloc(0x800, PageReadOnly|PageGuard); got != 0x1f0000 {
+		t.Errorf("Alloc(ReadOnly|Guard) = 0x%x", got)
+	}
+	m.Fill(0x1f0000, 0x100, 0xcc)
+	m.Copy(0x1f0100, 0x401000, 0x20)
+	m.SetProtection(0x1f0010, 0x1000, PageExecuteRead)
+	m.SaveToFile(`C:\dump\cave.bin`, 0x1f0000, 0x1000)
+	m.Free(0x1f0000)
+
+	want := []string{
+		"setpagerights 0x1f0000, GReadOnly",
+		"memset 0x1f0000, 0xcc, 0x100",
+		"memcpy 0x1f0100, 0x401000, 0x20",
+		"setpagerights 0x1f0000, ExecuteRead",
+		"setpagerights 0x1f1000, ExecuteRead",
+		`savedata "C:\\dump\\cave.bin", 0x1f0000, 0x1000`,
+		"free 0x1f0000",
+	}
+	if commands := p.bodies("/ExecCommand"); !reflect.DeepEqual(commands, want) {
+		t.Errorf("commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	p.reset()
+	failRights = true
+	if _, err := m.TryAlloc(0x2000, PageReadWrite); err == nil {
+		t.Error("Alloc succeeded although setpagerights failed")
+	}
+	if commands := p.bodies("/ExecCommand"); commands[len(commands)-1] != "free 0x1f0000" {
+		t.Errorf("failed Alloc left the allocation behind, commands %q", commands)
+	}
+	if _, err := m.TryAlloc(0x1000, PageReadWrite|PageNoCache); err == nil {
+		t.Error("Alloc accepted PAGE_NOCACHE")
+	}
+}
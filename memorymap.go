@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// PageProtection is the PAGE_* protection of a page, the low byte holds exactly one access value and
+// PageGuard, PageNoCache, PageWriteCombine are modifier bits on top of it.
+type PageProtection uint32
+
+const (
+	PageNoAccess         PageProtection = 0x01
+	PageReadOnly         PageProtection = 0x02
+	PageReadWrite        PageProtection = 0x04
+	PageWriteCopy        PageProtection = 0x08
+	PageExecute          PageProtection = 0x10
+	PageExecuteRead      PageProtection = 0x20
+	PageExecuteReadWrite PageProtection = 0x40
+	PageExecuteWriteCopy PageProtection = 0x80
+	PageGuard            PageProtection = 0x100
+	PageNoCache          PageProtection = 0x200
+	PageWriteCombine     PageProtection = 0x400
+
+	pageAccessMask PageProtection = 0xff
+)
+
+var pageProtectionNames = []struct {
+	p    PageProtection
+	name string
+}{
+	{PageNoAccess, "PAGE_NOACCESS"},
+	{PageReadOnly, "PAGE_READONLY"},
+	{PageReadWrite, "PAGE_READWRITE"},
+	{PageWriteCopy, "PAGE_WRITECOPY"},
+	{PageExecute, "PAGE_EXECUTE"},
+	{PageExecuteRead, "PAGE_EXECUTE_READ"},
+	{PageExecuteReadWrite, "PAGE_EXECUTE_READWRITE"},
+	{PageExecuteWriteCopy, "PAGE_EXECUTE_WRITECOPY"},
+	{PageGuard, "PAGE_GUARD"},
+	{PageNoCache, "PAGE_NOCACHE"},
+	{PageWriteCombine, "PAGE_WRITECOMBINE"},
+}
+
+// Access is p without the modifier bits.
+func (p PageProtection) Access() PageProtection { return p & pageAccessMask }
+
+func (p PageProtection) Readable() bool {
+	return p.Access()&(PageReadOnly|PageReadWrite|PageWriteCopy|PageExecuteRead|PageExecuteReadWrite|PageExecuteWriteCopy) != 0
+}
+
+func (p PageProtection) Writable() bool {
+	return p.Access()&(PageReadWrite|PageWriteCopy|PageExecuteReadWrite|PageExecuteWriteCopy) != 0
+}
+
+func (p PageProtection) Executable() bool {
+	return p.Access()&(PageExecute|PageExecuteRead|PageExecuteReadWrite|PageExecuteWriteCopy) != 0
+}
+
+func (p PageProtection) Guard() bool { return p&PageGuard != 0 }
+
+// String joins the PAGE_* names, PAGE_EXECUTE_READ|PAGE_GUARD, unknown bits are kept in hex.
+func (p PageProtection) String() string {
+	if p == 0 {
+		return "0"
+	}
+	var names []string
+	rest := p
+	for _, n := range pageProtectionNames {
+		if p&n.p != 0 {
+			names = append(names, n.name)
+			rest &^= n.p
+		}
+	}
+	if rest != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(rest), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// PageState is MEMORY_BASIC_INFORMATION.State.
+type PageState uint32
+
+const (
+	PageCommit  PageState = 0x1000
+	PageReserve PageState = 0x2000
+	PageFree    PageState = 0x10000
+)
+
+// PageType is MEMORY_BASIC_INFORMATION.Type, 0 for free regions.
+type PageType uint32
+
+const (
+	PagePrivate PageType = 0x20000
+	PageMapped  PageType = 0x40000
+	PageImage   PageType = 0x1000000
+)
+
+func (s PageState) String() string {
+	switch s {
+	case PageCommit:
+		return "MEM_COMMIT"
+	case PageReserve:
+		return "MEM_RESERVE"
+	case PageFree:
+		return "MEM_FREE"
+	}
+	return "PageState(0x" + strconv.FormatUint(uint64(s), 16) + ")"
+}
+
+func (t PageType) String() string {
+	switch t {
+	case 0:
+		return ""
+	case PagePrivate:
+		return "MEM_PRIVATE"
+	case PageMapped:
+		return "MEM_MAPPED"
+	case PageImage:
+		return "MEM_IMAGE"
+	}
+	return "PageType(0x" + strconv.FormatUint(uint64(t), 16) + ")"
+}
+
+// Contains reports whether address lies in r.
+func (r MemoryRegion) Contains(address int) bool {
+	return uint(r.BaseAddress) <= uint(address) && uint(address)-uint(r.BaseAddress) < uint(r.Size)
+}
+
+// Map returns every region of the debuggee's address space like the Memory Map tab of x64dbg.
+func (m memory) Map() []MemoryRegion {
+	return must(m.TryMap())
+}
+func (m memory) TryMap() ([]MemoryRegion, error) {
+	return m.MapContext(context.Background())
+}
+func (m memory) MapContext(ctx context.Context) ([]MemoryRegion, error) {
+	return tryRequest[[]MemoryRegion](m.client, ctx, "Memory/Map", nil)
+}
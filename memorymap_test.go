@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPageProtection(t *testing.T) {
+	cases := []struct {
+		p                        PageProtection
+		read, write, exec, guard bool
+		text                     string
+	}{
+		{PageNoAccess, false, false, false, false, "PAGE_NOACCESS"},
+		{PageReadOnly, true, false, false, false, "PAGE_READONLY"},
+		{PageReadWrite | PageGuard, true, true, false, true, "PAGE_READWRITE|PAGE_GUARD"},
+		{PageExecuteRead, true, false, true, false, "PAGE_EXECUTE_READ"},
+		{PageExecuteWriteCopy | PageNoCache, true, true, true, false, "PAGE_EXECUTE_WRITECOPY|PAGE_NOCACHE"},
+		{PageExecute | 0x40000000, false, false, true, false, "PAGE_EXECUTE|0x40000000"},
+	}
+	for _, c := range cases {
+		if c.p.Readable() != c.read || c.p.Writable() != c.write || c.p.Executable() != c.exec || c.p.Guard() != c.guard {
+			t.Errorf("%v: readable %v writable %v executable %v guard %v", c.p, c.p.Readable(), c.p.Writable(), c.p.Executable(), c.p.Guard())
+		}
+		if got := c.p.String(); got != c.text {
+			t.Errorf("String() = %q, want %q", got, c.text)
+		}
+	}
+}
+
+func TestMemoryMap(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "plugin", "Memory_Map.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := fakePlugin(t, map[string]string{
+		"/Memory/Map":        string(body),
+		"/Memory/GetProtect": "0x120",
+	}).X64dbg().Memory
+
+	regions := m.Map()
+	if len(regions) != 3 {
+		t.Fatalf("Map = %+v", regions)
+	}
+	text := regions[2]
+	if !text.Contains(0x7ff6a1b21fff) || text.Contains(0x7ff6a1b22000) || text.Type != PageImage || !text.Protect.Executable() {
+		t.Errorf("text region %+v", text)
+	}
+	if got := m.GetProtectFlag(0x2d4000); got != PageExecuteRead|PageGuard {
+		t.Errorf("GetProtectFlag = %v", got)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+const (
+	memoryPageSize = 0x1000
+	// Memory/Read answers "Size too large" above this
+	memoryMaxChunk = 1024 * 1024
+)
+
+// MemoryHole is a range a MemoryReader could not read.
+type MemoryHole struct {
+	Address HexInt
+	Size    int
+}
+
+// MemoryReader reads debuggee memory as an io.ReaderAt and io.Reader, offset 0 is the address passed to
+// memory.NewReader, so debug/pe.NewFile, io.Copy and bytes tooling work on a module in place.
+//
+// A read is split into ChunkSize requests with up to Parallel in flight. A chunk the plugin can not
+// read is retried page by page to find the unreadable pages exactly; ZeroFill reads them as zeroes and
+// records them in Holes, without it the read stops at the first hole with ErrInvalidAddress.
+type MemoryReader struct {
+	ChunkSize int  // bytes per request, default 64 KiB, at most 1 MiB
+	Parallel  int  // requests in flight, default 4
+	ZeroFill  bool // zero-fill unreadable pages instead of failing
+	Binary    bool // raw bytes instead of hex on the wire, needs a plugin with encoding=binary
+
+	m       memory
+	address int
+	size    int64
+	offset  int64
+
+	mu    sync.Mutex
+	holes []MemoryHole
+}
+
+// NewReader reads size bytes starting at address.
+func (m memory) NewReader(address int, size int64) *MemoryReader {
+	return &MemoryReader{ChunkSize: 64 * 1024, Parallel: 4, m: m, address: address, size: size}
+}
+
+func (r *MemoryReader) Size() int64 { return r.size }
+
+// Holes returns the unreadable ranges met so far, sorted by address.
+func (r *MemoryReader) Holes() []MemoryHole {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.holes)
+}
+
+func (r *MemoryReader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *MemoryReader) ReadAt(p []byte, off int64) (int, error) {
+	return r.ReadAtContext(context.Background(), p, off)
+}
+
+func (r *MemoryReader) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("x64dbg: MemoryReader: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	var eof error
+	if rest := r.size - off; int64(len(p)) > rest {
+		p, eof = p[:rest], io.EOF
+	}
+
+	chunk := min(max(r.ChunkSize, memoryPageSize), memoryMaxChunk)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		holes []MemoryHole
+		first error
+	)
+	sem := make(chan struct{}, max(r.Parallel, 1))
+	for start := 0; start < len(p); start += chunk {
+		dst := p[start:min(start+chunk, len(p))]
+		address := r.address + int(off) + start
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			h, err := r.readChunk(ctx, address, dst)
+			mu.Lock()
+			defer mu.Unlock()
+			holes = append(holes, h...)
+			if err != nil && first == nil {
+				first = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if first != nil {
+		return 0, first
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	slices.SortFunc(holes, compareHoles)
+	r.addHoles(holes)
+	if len(holes) > 0 && !r.ZeroFill {
+		n := int(holes[0].Address) - (r.address + int(off))
+		return n, fmt.Errorf("x64dbg: memory 0x%x: %w", uint(holes[0].Address), ErrInvalidAddress)
+	}
+	return len(p), eof
+}
+
+// readChunk fills dst from address and zeroes the pages it can not read, which it returns as holes.
+func (r *MemoryReader) readChunk(ctx context.Context, address int, dst []byte) ([]MemoryHole, error) {
+	b, err := r.fetch(ctx, address, len(dst))
+	if err == nil && len(b) == len(dst) {
+		copy(dst, b)
+		return nil, nil
+	}
+	if err != nil && !errors.Is(err, ErrInvalidAddress) {
+		return nil, err
+	}
+
+	var holes []MemoryHole
+	for start := 0; start < len(dst); {
+		// 按页重试，页边界对齐
+		end := min(start+memoryPageSize-(address+start)%memoryPageSize, len(dst))
+		page := dst[start:end]
+		b, err := r.fetch(ctx, address+start, len(page))
+		if err != nil && !errors.Is(err, ErrInvalidAddress) {
+			return nil, err
+		}
+		n := copy(page, b)
+		clear(page[n:])
+		if n < len(page) {
+			hole := MemoryHole{Address: HexInt(address + start + n), Size: len(page) - n}
+			if last := len(holes) - 1; last >= 0 && int(holes[last].Address)+holes[last].Size == int(hole.Address) {
+				holes[last].Size += hole.Size
+			} else {
+				holes = append(holes, hole)
+			}
+		}
+		start = end
+	}
+	return holes, nil
+}
+
+func (r *MemoryReader) fetch(ctx context.Context, address int, size int) ([]byte, error) {
+	params := map[string]string{"addr": fmt.Sprintf("0x%x", address), "size": strconv.Itoa(size)}
+	if r.Binary {
+		params["encoding"] = "binary"
+		return tryRequest[binaryBytes](r.m.client, ctx, "Memory/Read", params)
+	}
+	return tryRequest[HexBytes](r.m.client, ctx, "Memory/Read", params)
+}
+
+func compareHoles(a, b MemoryHole) int { return cmp.Compare(a.Address, b.Address) }
+
+// addHoles merges holes into the sorted hole list, a range read twice is listed once.
+func (r *MemoryReader) addHoles(holes []MemoryHole) {
+	if len(holes) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := append(r.holes, holes...)
+	slices.SortFunc(all, compareHoles)
+	merged := all[:0]
+	for _, h := range all {
+		if last := len(merged) - 1; last >= 0 && int(merged[last].Address)+merged[last].Size >= int(h.Address) {
+			merged[last].Size = max(merged[last].Size, int(h.Address)+h.Size-int(merged[last].Address))
+			continue
+		}
+		merged = append(merged, h)
+	}
+	r.holes = merged
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeMemory serves Memory/Read and Memory/Write from image mapped at base, pages listed in unmapped
+// fail like Script::Memory::Read does. x86 answers the RIP probe like the x32dbg build of the plugin.
+type fakeMemory struct {
+	base     int
+	image    []byte
+	unmapped map[int]bool
+	x86      bool
+	requests atomic.Int32
+	binary   atomic.Int32
+}
+
+func (f *fakeMemory) client(t *testing.T) x64dbg {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests.Add(1)
+		q := r.URL.Query()
+		addr, _ := strconv.ParseUint(strings.TrimPrefix(q.Get("addr"), "0x"), 16, 64)
+		switch r.URL.Path {
+		case "/Register/Get":
+			if f.x86 {
+				http.Error(w, "Unknown register", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("0x401000"))
+			return
+		case "/IsDebugActive":
+			w.Write([]byte("true"))
+			return
+		case "/Memory/Write":
+			body, _ := io.ReadAll(r.Body)
+			data, _ := hex.DecodeString(string(body))
+			copy(f.image[int(addr)-f.base:], data)
+			w.Write([]byte("Memory written successfully"))
+			return
+		}
+		size, _ := strconv.Atoi(q.Get("size"))
+		if size > memoryMaxChunk {
+			http.Error(w, "Size too large", http.StatusBadRequest)
+			return
+		}
+		for page := int(addr) &^ (memoryPageSize - 1); page < int(addr)+size; page += memoryPageSize {
+			if f.unmapped[page] {
+				http.Error(w, "Failed to read memory", http.StatusInternalServerError)
+				return
+			}
+		}
+		data := f.image[int(addr)-f.base : int(addr)-f.base+size]
+		if q.Get("encoding") == "binary" {
+			f.binary.Add(1)
+			w.Write(data)
+			return
+		}
+		w.Write([]byte(hex.EncodeToString(data)))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL).X64dbg()
+}
+
+func newFakeMemory(size int, unmapped ...int) *fakeMemory {
+	f := &fakeMemory{base: 0x400000, image: make([]byte, size), unmapped: map[int]bool{}}
+	for i := range f.image {
+		f.image[i] = byte(i*7 + i>>8)
+	}
+	for _, page := range unmapped {
+		f.unmapped[page] = true
+	}
+	return f
+}
+
+func TestMemoryReader(t *testing.T) {
+	f := newFakeMemory(0x40000)
+	m := f.client(t).Memory
+
+	r := m.NewReader(f.base, int64(len(f.image)))
+	r.ChunkSize = 0x8000
+	r.Parallel = 3
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, f.image) {
+		t.Fatalf("ReadAll: %d bytes, %v", len(got), err)
+	}
+
+	f.requests.Store(0)
+	buf := make([]byte, 0x9000)
+	if n, err := r.ReadAt(buf, 0x1234); n != len(buf) || err != nil || !bytes.Equal(buf, f.image[0x1234:0x1234+len(buf)]) {
+		t.Errorf("ReadAt: %d, %v", n, err)
+	}
+	if n := f.requests.Load(); n != 2 {
+		t.Errorf("ReadAt 0x9000 bytes in %d requests, want 2 chunks", n)
+	}
+
+	if n, err := r.ReadAt(buf, int64(len(f.image))-0x10); n != 0x10 || err != io.EOF {
+		t.Errorf("ReadAt past the end: %d, %v, want 16, EOF", n, err)
+	}
+	if _, err := r.ReadAt(buf, int64(len(f.image))); err != io.EOF {
+		t.Errorf("ReadAt at the end: %v, want EOF", err)
+	}
+
+	r.Binary = true
+	if n, err := r.ReadAt(buf, 0); n != len(buf) || err != nil || !bytes.Equal(buf, f.image[:len(buf)]) {
+		t.Errorf("binary ReadAt: %d, %v", n, err)
+	}
+	if f.binary.Load() == 0 {
+		t.Error("Binary did not ask for encoding=binary")
+	}
+}
+
+func TestMemoryReaderHoles(t *testing.T) {
+	f := newFakeMemory(0x10000, 0x403000, 0x404000, 0x409000)
+	m := f.client(t).Memory
+
+	r := m.NewReader(f.base, int64(len(f.image)))
+	r.ZeroFill = true
+	r.ChunkSize = 0x2000
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bytes.Clone(f.image)
+	clear(want[0x3000:0x5000])
+	clear(want[0x9000:0xa000])
+	if !bytes.Equal(got, want) {
+		t.Error("holes not zero filled or readable pages lost")
+	}
+	wantHoles := []MemoryHole{{Address: 0x403000, Size: 0x2000}, {Address: 0x409000, Size: 0x1000}}
+	if !reflect.DeepEqual(r.Holes(), wantHoles) {
+		t.Errorf("Holes = %+v, want %+v", r.Holes(), wantHoles)
+	}
+
+	strict := m.NewReader(f.base, int64(len(f.image)))
+	buf := make([]byte, 0x8000)
+	n, err := strict.ReadAt(buf, 0x1000)
+	if n != 0x2000 || !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("strict ReadAt: %d, %v, want 0x2000 bytes and ErrInvalidAddress", n, err)
+	}
+	if !bytes.Equal(buf[:n], f.image[0x1000:0x3000]) {
+		t.Error("bytes before the hole differ")
+	}
+}
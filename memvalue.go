@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// Number is a fixed size type ReadValue and WriteValue move, int and uint have no fixed size in
+// the debuggee, use ReadPtr for pointer sized values.
+type Number interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~int64 | ~uint64 | ~float32 | ~float64
+}
+
+// DebuggeeByteOrder is the byte order of x86 and x64 debuggees.
+var DebuggeeByteOrder binary.ByteOrder = binary.LittleEndian
+
+// readFull reads exactly size bytes at address, a short answer is an unreadable address.
+func (m memory) readFull(ctx context.Context, address int, size int) ([]byte, error) {
+	b, err := m.ReadContext(ctx, address, uint(size))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < size {
+		return nil, fmt.Errorf("x64dbg: memory 0x%x: read %d of %d bytes: %w", address, len(b), size, ErrInvalidAddress)
+	}
+	return b[:size], nil
+}
+
+// ReadValue reads a T at address, methods can not have type parameters so it takes the memory facade.
+func ReadValue[T Number](m memory, address int) T {
+	return must(TryReadValue[T](m, address))
+}
+func TryReadValue[T Number](m memory, address int) (T, error) {
+	return ReadValueContext[T](context.Background(), m, address)
+}
+func ReadValueContext[T Number](ctx context.Context, m memory, address int) (T, error) {
+	var v T
+	return v, m.ReadStructContext(ctx, address, &v)
+}
+func WriteValue[T Number](m memory, address int, v T) {
+	mylog.Check(TryWriteValue(m, address, v))
+}
+func TryWriteValue[T Number](m memory, address int, v T) error {
+	return WriteValueContext(context.Background(), m, address, v)
+}
+func WriteValueContext[T Number](ctx context.Context, m memory, address int, v T) error {
+	return m.WriteStructContext(ctx, address, v)
+}
+
+// ReadStruct decodes the debuggee memory at address into v like binary.Read with DebuggeeByteOrder,
+// v is a pointer to a fixed size value: numbers, arrays and structs of them, _ fields are skipped.
+// Pointer fields must be declared uint32 or uint64 to match the debuggee.
+func (m memory) ReadStruct(address int, v any) { mylog.Check(m.TryReadStruct(address, v)) }
+func (m memory) TryReadStruct(address int, v any) error {
+	return m.ReadStructContext(context.Background(), address, v)
+}
+func (m memory) ReadStructContext(ctx context.Context, address int, v any) error {
+	size := binary.Size(v)
+	if size < 0 {
+		return fmt.Errorf("x64dbg: ReadStruct: %T is not a fixed size type", v)
+	}
+	b, err := m.readFull(ctx, address, size)
+	if err != nil {
+		return err
+	}
+	_, err = binary.Decode(b, DebuggeeByteOrder, v)
+	return err
+}
+
+// WriteStruct encodes v with DebuggeeByteOrder and writes it at address, see ReadStruct.
+func (m memory) WriteStruct(address int, v any) { mylog.Check(m.TryWriteStruct(address, v)) }
+func (m memory) TryWriteStruct(address int, v any) error {
+	return m.WriteStructContext(context.Background(), address, v)
+}
+func (m memory) WriteStructContext(ctx context.Context, address int, v any) error {
+	b, err := binary.Append(nil, DebuggeeByteOrder, v)
+	if err != nil {
+		return fmt.Errorf("x64dbg: WriteStruct: %w", err)
+	}
+	_, err = m.WriteContext(ctx, address, b)
+	return err
+}
+
+// PointerSize is 8 under x64dbg and 4 under x32dbg, see Client.Bits.
+func (m memory) PointerSize() int {
+	return must(m.TryPointerSize())
+}
+func (m memory) TryPointerSize() (int, error) {
+	return m.PointerSizeContext(context.Background())
+}
+func (m memory) PointerSizeContext(ctx context.Context) (int, error) {
+	bits, err := m.client.BitsContext(ctx)
+	return bits / 8, err
+}
+
+// ReadPtr reads a pointer of the debuggee's width at address.
+func (m memory) ReadPtr(address int) HexInt {
+	return must(m.TryReadPtr(address))
+}
+func (m memory) TryReadPtr(address int) (HexInt, error) {
+	return m.ReadPtrContext(context.Background(), address)
+}
+func (m memory) ReadPtrContext(ctx context.Context, address int) (HexInt, error) {
+	size, err := m.PointerSizeContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if size == 4 {
+		v, err := ReadValueContext[uint32](ctx, m, address)
+		return HexInt(v), err
+	}
+	v, err := ReadValueContext[uint64](ctx, m, address)
+	return HexInt(v), err
+}
+
+// readUntil reads units of unit bytes from address until a zero unit or max units, page by page so
+// a string ending just before an unmapped page is still read.
+func (m memory) readUntil(ctx context.Context, address int, unit int, max int) ([]byte, error) {
+	var out []byte
+	for addr := address; len(out) < max*unit; {
+		n := min(memoryPageSize-addr%memoryPageSize, max*unit-len(out))
+		n -= n % unit
+		if n == 0 {
+			// 跨页的 wchar，读整一个
+			n = unit
+		}
+		b, err := m.readFull(ctx, addr, n)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+unit <= len(b); i += unit {
+			if isZero(b[i : i+unit]) {
+				return append(out, b[:i]...), nil
+			}
+		}
+		out = append(out, b...)
+		addr += n
+	}
+	return out, nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadCString reads a NUL terminated byte string of at most max bytes, the bytes are returned as is.
+func (m memory) ReadCString(address int, max int) string {
+	return must(m.TryReadCString(address, max))
+}
+func (m memory) TryReadCString(address int, max int) (string, error) {
+	return m.ReadCStringContext(context.Background(), address, max)
+}
+func (m memory) ReadCStringContext(ctx context.Context, address int, max int) (string, error) {
+	b, err := m.readUntil(ctx, address, 1, max)
+	return string(b), err
+}
+
+// ReadWString reads a NUL terminated UTF-16 string of at most max code units.
+func (m memory) ReadWString(address int, max int) string {
+	return must(m.TryReadWString(address, max))
+}
+func (m memory) TryReadWString(address int, max int) (string, error) {
+	return m.ReadWStringContext(context.Background(), address, max)
+}
+func (m memory) ReadWStringContext(ctx context.Context, address int, max int) (string, error) {
+	b, err := m.readUntil(ctx, address, 2, max)
+	if err != nil {
+		return "", err
+	}
+	return decodeUTF16(b), nil
+}
+
+// ReadUTF16 reads exactly length code units, NULs included, as for the Buffer of a UNICODE_STRING
+// whose Length is length*2.
+func (m memory) ReadUTF16(address int, length int) string {
+	return must(m.TryReadUTF16(address, length))
+}
+func (m memory) TryReadUTF16(address int, length int) (string, error) {
+	return m.ReadUTF16Context(context.Background(), address, length)
+}
+func (m memory) ReadUTF16Context(ctx context.Context, address int, length int) (string, error) {
+	if length == 0 {
+		return "", nil
+	}
+	b, err := m.readFull(ctx, address, length*2)
+	if err != nil {
+		return "", err
+	}
+	return decodeUTF16(b), nil
+}
+
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = DebuggeeByteOrder.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestMemoryValues(t *testing.T) {
+	f := newFakeMemory(0x3000, 0x402000)
+	m := f.client(t).Memory
+
+	WriteValue(m, 0x400010, uint32(0xdeadbeef))
+	if got := ReadValue[uint32](m, 0x400010); got != 0xdeadbeef {
+		t.Errorf("uint32 = 0x%x", got)
+	}
+	if got := ReadValue[uint16](m, 0x400012); got != 0xdead {
+		t.Errorf("uint16 little endian = 0x%x", got)
+	}
+	WriteValue(m, 0x400020, -2.5)
+	if got := ReadValue[float64](m, 0x400020); got != -2.5 {
+		t.Errorf("float64 = %v", got)
+	}
+	WriteValue(m, 0x400030, int8(-1))
+	if got := ReadValue[int8](m, 0x400030); got != -1 {
+		t.Errorf("int8 = %v", got)
+	}
+	if _, err := TryReadValue[uint64](m, 0x401ffc); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("read across an unmapped page: %v, want ErrInvalidAddress", err)
+	}
+
+	// UNICODE_STRING of a 64 bit debuggee
+	type unicodeString struct {
+		Length        uint16
+		MaximumLength uint16
+		_             uint32
+		Buffer        uint64
+	}
+	want := unicodeString{Length: 10, MaximumLength: 12, Buffer: 0x401000}
+	m.WriteStruct(0x400100, want)
+	var got unicodeString
+	m.ReadStruct(0x400100, &got)
+	if got != want {
+		t.Errorf("ReadStruct = %+v, want %+v", got, want)
+	}
+	if b := f.image[0x100:0x110]; binary.LittleEndian.Uint64(b[8:]) != 0x401000 || b[0] != 10 {
+		t.Errorf("WriteStruct wrote % x", b)
+	}
+	if err := m.TryReadStruct(0x400100, &struct{ P uintptr }{}); err == nil {
+		t.Error("ReadStruct of a pointer sized field succeeded")
+	}
+
+	if got := m.ReadPtr(0x400100); got != HexInt(binary.LittleEndian.Uint64(f.image[0x100:])) {
+		t.Errorf("ReadPtr x64 = 0x%x", got)
+	}
+	// 同一地址换成 x32dbg，要重新 Connect
+	f.x86 = true
+	if got := m.PointerSize(); got != 8 {
+		t.Errorf("PointerSize before Connect = %d, want the remembered 8", got)
+	}
+	if err := m.client.Connect(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.PointerSize(); got != 4 {
+		t.Errorf("PointerSize x86 = %d", got)
+	}
+	if got := m.ReadPtr(0x400100); got != 0x000c000a {
+		t.Errorf("ReadPtr x86 = 0x%x", got)
+	}
+	f.x86 = false
+	m.client.Connect(t.Context())
+	if got := m.PointerSize(); got != 8 {
+		t.Errorf("PointerSize x64 = %d", got)
+	}
+}
+
+func TestMemoryStrings(t *testing.T) {
+	f := newFakeMemory(0x3000, 0x402000)
+	m := f.client(t).Memory
+
+	copy(f.image[0x200:], "hello\x00world")
+	if got := m.ReadCString(0x400200, 64); got != "hello" {
+		t.Errorf("ReadCString = %q", got)
+	}
+	if got := m.ReadCString(0x400200, 3); got != "hel" {
+		t.Errorf("ReadCString max 3 = %q", got)
+	}
+
+	// a string that ends right before the unmapped page is read, one running into it is not
+	copy(f.image[0x1ffc:], "abc\x00")
+	if got := m.ReadCString(0x401ffc, 100); got != "abc" {
+		t.Errorf("ReadCString at the page end = %q", got)
+	}
+	copy(f.image[0x1ffc:], "abcd")
+	if _, err := m.TryReadCString(0x401ffc, 100); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("unterminated before an unmapped page: %v, want ErrInvalidAddress", err)
+	}
+	if got := m.ReadCString(0x401ffc, 4); got != "abcd" {
+		t.Errorf("ReadCString stopped by max = %q", got)
+	}
+
+	text := "Grüße 𝄞"
+	units := utf16.Encode([]rune(text))
+	b := make([]byte, 0, 2*len(units)+2)
+	for _, u := range units {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	copy(f.image[0x301:], append(b, 0, 0, 'x', 0))
+	if got := m.ReadWString(0x400301, 100); got != text {
+		t.Errorf("ReadWString = %q", got)
+	}
+	if got := m.ReadWString(0x400301, 2); got != "Gr" {
+		t.Errorf("ReadWString max 2 = %q", got)
+	}
+	if got := m.ReadUTF16(0x400301, len(units)+2); got != text+"\x00x" {
+		t.Errorf("ReadUTF16 = %q", got)
+	}
+	if got := m.ReadUTF16(0x400301, 0); got != "" {
+		t.Errorf("ReadUTF16 empty = %q", got)
+	}
+
+	// a wchar_t straddling a page boundary
+	copy(f.image[0xfff:], []byte{'A', 0, 'B', 0, 0, 0})
+	if got := m.ReadWString(0x400fff, 10); got != "AB" {
+		t.Errorf("ReadWString across a page = %q", got)
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ModuleRef selects the module a Module/* call works on: any address inside it (the base works too), its
+// name or the main module. The zero value, which ModuleAt(0) is too, selects nothing and gets ErrNotFound.
+type ModuleRef struct {
+	Addr int
+	Name string
+	Main bool
+}
+
+func ModuleAt(address int) ModuleRef    { return ModuleRef{Addr: address} }
+func ModuleNamed(name string) ModuleRef { return ModuleRef{Name: name} }
+func MainModule() ModuleRef             { return ModuleRef{Main: true} }
+
+func (r ModuleRef) params() (map[string]string, error) {
+	switch {
+	case r.Addr != 0:
+		return map[string]string{"addr": fmt.Sprintf("0x%x", r.Addr)}, nil
+	case r.Name != "":
+		return map[string]string{"name": r.Name}, nil
+	case r.Main:
+		return map[string]string{"main": "1"}, nil
+	}
+	return nil, fmt.Errorf("x64dbg: no module at address 0: %w", ErrNotFound)
+}
+
+func (m module) Info(ref ModuleRef) moduleInfo {
+	return must(m.TryInfo(ref))
+}
+func (m module) TryInfo(ref ModuleRef) (moduleInfo, error) {
+	return m.InfoContext(context.Background(), ref)
+}
+func (m module) InfoContext(ctx context.Context, ref ModuleRef) (moduleInfo, error) {
+	params, err := ref.params()
+	if err != nil {
+		return moduleInfo{}, err
+	}
+	return tryRequest[moduleInfo](m.client, ctx, "Module/Info", params)
+}
+func (m module) SectionList(ref ModuleRef) []moduleSectionInfo {
+	return must(m.TrySectionList(ref))
+}
+func (m module) TrySectionList(ref ModuleRef) ([]moduleSectionInfo, error) {
+	return m.SectionListContext(context.Background(), ref)
+}
+func (m module) SectionListContext(ctx context.Context, ref ModuleRef) ([]moduleSectionInfo, error) {
+	params, err := ref.params()
+	if err != nil {
+		return nil, err
+	}
+	return tryRequest[[]moduleSectionInfo](m.client, ctx, "Module/SectionList", params)
+}
+func (m module) Section(ref ModuleRef, number int) moduleSectionInfo {
+	return must(m.TrySection(ref, number))
+}
+func (m module) TrySection(ref ModuleRef, number int) (moduleSectionInfo, error) {
+	return m.SectionContext(context.Background(), ref, number)
+}
+func (m module) SectionContext(ctx context.Context, ref ModuleRef, number int) (moduleSectionInfo, error) {
+	params, err := ref.params()
+	if err != nil {
+		return moduleSectionInfo{}, err
+	}
+	params["number"] = strconv.Itoa(number)
+	return tryRequest[moduleSectionInfo](m.client, ctx, "Module/Section", params)
+}
+
+// Exports lists the export table, forwarded exports carry the target in ForwardName ("NTDLL.RtlAllocateHeap") and have no Va.
+func (m module) Exports(ref ModuleRef) []moduleExport {
+	return must(m.TryExports(ref))
+}
+func (m module) TryExports(ref ModuleRef) ([]moduleExport, error) {
+	return m.ExportsContext(context.Background(), ref)
+}
+func (m module) ExportsContext(ctx context.Context, ref ModuleRef) ([]moduleExport, error) {
+	params, err := ref.params()
+	if err != nil {
+		return nil, err
+	}
+	return tryRequest[[]moduleExport](m.client, ctx, "Module/Exports", params)
+}
+
+// Imports lists the import table, Ordinal is 0xffffffffffffffff for imports by name.
+func (m module) Imports(ref ModuleRef) []moduleImport {
+	return must(m.TryImports(ref))
+}
+func (m module) TryImports(ref ModuleRef) ([]moduleImport, error) {
+	return m.ImportsContext(context.Background(), ref)
+}
+func (m module) ImportsContext(ctx context.Context, ref ModuleRef) ([]moduleImport, error) {
+	params, err := ref.params()
+	if err != nil {
+		return nil, err
+	}
+	return tryRequest[[]moduleImport](m.client, ctx, "Module/Imports", params)
+}
+func (m module) GetList() []moduleInfo {
+	return must(m.TryGetList())
+}
+func (m module) TryGetList() ([]moduleInfo, error) {
+	return m.GetListContext(context.Background())
+}
+func (m module) GetListContext(ctx context.Context) ([]moduleInfo, error) {
+	return tryRequest[[]moduleInfo](m.client, ctx, "Module/GetList", nil)
+}
+
+// 下面是按 Script::Module 接口命名的快捷方法，都落到上面几个请求上
+
+func (m module) InfoFromAddr(address int) moduleInfo {
+	return must(m.TryInfoFromAddr(address))
+}
+func (m module) TryInfoFromAddr(address int) (moduleInfo, error) {
+	return m.InfoFromAddrContext(context.Background(), address)
+}
+func (m module) InfoFromAddrContext(ctx context.Context, address int) (moduleInfo, error) {
+	return m.InfoContext(ctx, ModuleAt(address))
+}
+func (m module) BaseFromAddr(address int) HexInt {
+	return must(m.TryBaseFromAddr(address))
+}
+func (m module) TryBaseFromAddr(address int) (HexInt, error) {
+	return m.BaseFromAddrContext(context.Background(), address)
+}
+func (m module) BaseFromAddrContext(ctx context.Context, address int) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return info.BaseAddress, err
+}
+func (m module) SizeFromAddr(address int) HexInt {
+	return must(m.TrySizeFromAddr(address))
+}
+func (m module) TrySizeFromAddr(address int) (HexInt, error) {
+	return m.SizeFromAddrContext(context.Background(), address)
+}
+func (m module) SizeFromAddrContext(ctx context.Context, address int) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return info.Size, err
+}
+func (m module) NameFromAddr(address int) string {
+	return must(m.TryNameFromAddr(address))
+}
+func (m module) TryNameFromAddr(address int) (string, error) {
+	return m.NameFromAddrContext(context.Background(), address)
+}
+func (m module) NameFromAddrContext(ctx context.Context, address int) (string, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return info.Name, err
+}
+func (m module) PathFromAddr(address int) string {
+	return must(m.TryPathFromAddr(address))
+}
+func (m module) TryPathFromAddr(address int) (string, error) {
+	return m.PathFromAddrContext(context.Background(), address)
+}
+func (m module) PathFromAddrContext(ctx context.Context, address int) (string, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return info.Path, err
+}
+func (m module) EntryFromAddr(address int) HexInt {
+	return must(m.TryEntryFromAddr(address))
+}
+func (m module) TryEntryFromAddr(address int) (HexInt, error) {
+	return m.EntryFromAddrContext(context.Background(), address)
+}
+func (m module) EntryFromAddrContext(ctx context.Context, address int) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return info.Entry, err
+}
+func (m module) SectionCountFromAddr(address int) HexInt {
+	return must(m.TrySectionCountFromAddr(address))
+}
+func (m module) TrySectionCountFromAddr(address int) (HexInt, error) {
+	return m.SectionCountFromAddrContext(context.Background(), address)
+}
+func (m module) SectionCountFromAddrContext(ctx context.Context, address int) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleAt(address))
+	return HexInt(info.SectionCount), err
+}
+func (m module) SectionFromAddr(address int, number int) moduleSectionInfo {
+	return must(m.TrySectionFromAddr(address, number))
+}
+func (m module) TrySectionFromAddr(address int, number int) (moduleSectionInfo, error) {
+	return m.SectionFromAddrContext(context.Background(), address, number)
+}
+func (m module) SectionFromAddrContext(ctx context.Context, address int, number int) (moduleSectionInfo, error) {
+	return m.SectionContext(ctx, ModuleAt(address), number)
+}
+func (m module) SectionListFromAddr(address int) []moduleSectionInfo {
+	return must(m.TrySectionListFromAddr(address))
+}
+func (m module) TrySectionListFromAddr(address int) ([]moduleSectionInfo, error) {
+	return m.SectionListFromAddrContext(context.Background(), address)
+}
+func (m module) SectionListFromAddrContext(ctx context.Context, address int) ([]moduleSectionInfo, error) {
+	return m.SectionListContext(ctx, ModuleAt(address))
+}
+func (m module) InfoFromName(name string) moduleInfo {
+	return must(m.TryInfoFromName(name))
+}
+func (m module) TryInfoFromName(name string) (moduleInfo, error) {
+	return m.InfoFromNameContext(context.Background(), name)
+}
+func (m module) InfoFromNameContext(ctx context.Context, name string) (moduleInfo, error) {
+	return m.InfoContext(ctx, ModuleNamed(name))
+}
+func (m module) BaseFromName(name string) HexInt {
+	return must(m.TryBaseFromName(name))
+}
+func (m module) TryBaseFromName(name string) (HexInt, error) {
+	return m.BaseFromNameContext(context.Background(), name)
+}
+func (m module) BaseFromNameContext(ctx context.Context, name string) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleNamed(name))
+	return info.BaseAddress, err
+}
+func (m module) SizeFromName(name string) HexInt {
+	return must(m.TrySizeFromName(name))
+}
+func (m module) TrySizeFromName(name string) (HexInt, error) {
+	return m.SizeFromNameContext(context.Background(), name)
+}
+func (m module) SizeFromNameContext(ctx context.Context, name string) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleNamed(name))
+	return info.Size, err
+}
+func (m module) PathFromName(name string) string {
+	return must(m.TryPathFromName(name))
+}
+func (m module) TryPathFromName(name string) (string, error) {
+	return m.PathFromNameContext(context.Background(), name)
+}
+func (m module) PathFromNameContext(ctx context.Context, name string) (string, error) {
+	info, err := m.InfoContext(ctx, ModuleNamed(name))
+	return info.Path, err
+}
+func (m module) EntryFromName(name string) HexInt {
+	return must(m.TryEntryFromName(name))
+}
+func (m module) TryEntryFromName(name string) (HexInt, error) {
+	return m.EntryFromNameContext(context.Background(), name)
+}
+func (m module) EntryFromNameContext(ctx context.Context, name string) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleNamed(name))
+	return info.Entry, err
+}
+func (m module) SectionCountFromName(name string) HexInt {
+	return must(m.TrySectionCountFromName(name))
+}
+func (m module) TrySectionCountFromName(name string) (HexInt, error) {
+	return m.SectionCountFromNameContext(context.Background(), name)
+}
+func (m module) SectionCountFromNameContext(ctx context.Context, name string) (HexInt, error) {
+	info, err := m.InfoContext(ctx, ModuleNamed(name))
+	return HexInt(info.SectionCount), err
+}
+func (m module) SectionFromName(name string, number int) moduleSectionInfo {
+	return must(m.TrySectionFromName(name, number))
+}
+func (m module) TrySectionFromName(name string, number int) (moduleSectionInfo, error) {
+	return m.SectionFromNameContext(context.Background(), name, number)
+}
+func (m module) SectionFromNameContext(ctx context.Context, name string, number int) (moduleSectionInfo, error) {
+	return m.SectionContext(ctx, ModuleNamed(name), number)
+}
+func (m module) SectionListFromName(name string) []moduleSectionInfo {
+	return must(m.TrySectionListFromName(name))
+}
+func (m module) TrySectionListFromName(name string) ([]moduleSectionInfo, error) {
+	return m.SectionListFromNameContext(context.Background(), name)
+}
+func (m module) SectionListFromNameContext(ctx context.Context, name string) ([]moduleSectionInfo, error) {
+	return m.SectionListContext(ctx, ModuleNamed(name))
+}
+func (m module) GetMainModuleInfo() moduleInfo {
+	return must(m.TryGetMainModuleInfo())
+}
+func (m module) TryGetMainModuleInfo() (moduleInfo, error) {
+	return m.GetMainModuleInfoContext(context.Background())
+}
+func (m module) GetMainModuleInfoContext(ctx context.Context) (moduleInfo, error) {
+	return m.InfoContext(ctx, MainModule())
+}
+func (m module) GetMainModuleBase() HexInt {
+	return must(m.TryGetMainModuleBase())
+}
+func (m module) TryGetMainModuleBase() (HexInt, error) {
+	return m.GetMainModuleBaseContext(context.Background())
+}
+func (m module) GetMainModuleBaseContext(ctx context.Context) (HexInt, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.BaseAddress, err
+}
+func (m module) GetMainModuleSize() HexInt {
+	return must(m.TryGetMainModuleSize())
+}
+func (m module) TryGetMainModuleSize() (HexInt, error) {
+	return m.GetMainModuleSizeContext(context.Background())
+}
+func (m module) GetMainModuleSizeContext(ctx context.Context) (HexInt, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.Size, err
+}
+func (m module) GetMainModuleEntry() HexInt {
+	return must(m.TryGetMainModuleEntry())
+}
+func (m module) TryGetMainModuleEntry() (HexInt, error) {
+	return m.GetMainModuleEntryContext(context.Background())
+}
+func (m module) GetMainModuleEntryContext(ctx context.Context) (HexInt, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.Entry, err
+}
+func (m module) GetMainModuleSectionCount() int {
+	return must(m.TryGetMainModuleSectionCount())
+}
+func (m module) TryGetMainModuleSectionCount() (int, error) {
+	return m.GetMainModuleSectionCountContext(context.Background())
+}
+func (m module) GetMainModuleSectionCountContext(ctx context.Context) (int, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.SectionCount, err
+}
+func (m module) GetMainModuleName() string {
+	return must(m.TryGetMainModuleName())
+}
+func (m module) TryGetMainModuleName() (string, error) {
+	return m.GetMainModuleNameContext(context.Background())
+}
+func (m module) GetMainModuleNameContext(ctx context.Context) (string, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.Name, err
+}
+func (m module) GetMainModulePath() string {
+	return must(m.TryGetMainModulePath())
+}
+func (m module) TryGetMainModulePath() (string, error) {
+	return m.GetMainModulePathContext(context.Background())
+}
+func (m module) GetMainModulePathContext(ctx context.Context) (string, error) {
+	info, err := m.InfoContext(ctx, MainModule())
+	return info.Path, err
+}
+func (m module) GetMainModuleSectionList() []moduleSectionInfo {
+	return must(m.TryGetMainModuleSectionList())
+}
+func (m module) TryGetMainModuleSectionList() ([]moduleSectionInfo, error) {
+	return m.GetMainModuleSectionListContext(context.Background())
+}
+func (m module) GetMainModuleSectionListContext(ctx context.Context) ([]moduleSectionInfo, error) {
+	return m.SectionListContext(ctx, MainModule())
+}
+func (m module) GetExports(mod moduleInfo) []moduleExport {
+	return must(m.TryGetExports(mod))
+}
+func (m module) TryGetExports(mod moduleInfo) ([]moduleExport, error) {
+	return m.GetExportsContext(context.Background(), mod)
+}
+func (m module) GetExportsContext(ctx context.Context, mod moduleInfo) ([]moduleExport, error) {
+	return m.ExportsContext(ctx, ModuleAt(int(mod.BaseAddress)))
+}
+func (m module) GetImports(mod moduleInfo) []moduleImport {
+	return must(m.TryGetImports(mod))
+}
+func (m module) TryGetImports(mod moduleInfo) ([]moduleImport, error) {
+	return m.GetImportsContext(context.Background(), mod)
+}
+func (m module) GetImportsContext(ctx context.Context, mod moduleInfo) ([]moduleImport, error) {
+	return m.ImportsContext(ctx, ModuleAt(int(mod.BaseAddress)))
+}
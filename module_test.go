@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	fakeMain = moduleInfo{Name: "a.exe", BaseAddress: 0x400000, Size: 0x5000, Entry: 0x401000, SectionCount: 2, Path: `C:\a.exe`}
+	fakeDll  = moduleInfo{Name: "kernel32.dll", BaseAddress: 0x7ff000000000, Size: 0x10000, Entry: 0x7ff000001000, SectionCount: 1, Path: `C:\Windows\System32\kernel32.dll`}
+
+	fakeSections = map[HexInt][]moduleSectionInfo{
+		fakeMain.BaseAddress: {{Name: ".text", Address: 0x401000, Size: 0x1000}, {Name: ".data", Address: 0x402000, Size: 0x200}},
+		fakeDll.BaseAddress:  {{Name: ".text", Address: 0x7ff000001000, Size: 0x8000}},
+	}
+	fakeExports = []moduleExport{
+		{Ordinal: 1, Rva: 0x1010, Va: 0x7ff000001010, Name: "CreateFileW", UndecoratedName: "CreateFileW"},
+		{Ordinal: 2, Forwarded: true, ForwardName: "NTDLL.RtlAllocateHeap", Name: "HeapAlloc", UndecoratedName: "HeapAlloc"},
+	}
+	fakeImports = []moduleImport{
+		{IatRva: 0x3000, IatVa: 0x403000, Ordinal: ^HexInt(0), Name: "CreateFileW", UndecoratedName: "CreateFileW"},
+		{IatRva: 0x3008, IatVa: 0x403008, Ordinal: 0x10, Name: "", UndecoratedName: ""},
+	}
+)
+
+// fakeModulePlugin answers Module/* like resolveModule in MCPx64dbg.cpp: addr, name or main.
+func fakeModulePlugin(t *testing.T) x64dbg {
+	t.Helper()
+	modules := []moduleInfo{fakeMain, fakeDll}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply := func(v any) {
+			b, _ := json.Marshal(v)
+			w.Write(b)
+		}
+		if r.URL.Path == "/Module/GetList" {
+			reply(modules)
+			return
+		}
+
+		q := r.URL.Query()
+		mod, found := fakeMain, true
+		switch {
+		case !q.Has("addr") && !q.Has("name") && !q.Has("main"):
+			http.Error(w, "Missing addr, name or main parameter", http.StatusUnprocessableEntity)
+			return
+		case q.Has("addr"):
+			addr, err := strconv.ParseUint(strings.TrimPrefix(q.Get("addr"), "0x"), 16, 64)
+			if err != nil {
+				http.Error(w, "Invalid address format", http.StatusBadRequest)
+				return
+			}
+			found = false
+			for _, m := range modules {
+				if uint64(m.BaseAddress) <= addr && addr < uint64(m.BaseAddress+m.Size) {
+					mod, found = m, true
+				}
+			}
+		case q.Has("name"):
+			found = false
+			for _, m := range modules {
+				if strings.EqualFold(m.Name, q.Get("name")) {
+					mod, found = m, true
+				}
+			}
+		}
+		if !found {
+			http.Error(w, "Module not found", http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/Module/Info":
+			reply(mod)
+		case "/Module/SectionList":
+			reply(fakeSections[mod.BaseAddress])
+		case "/Module/Section":
+			n, _ := strconv.Atoi(q.Get("number"))
+			if n < 0 || n >= len(fakeSections[mod.BaseAddress]) {
+				http.Error(w, "Section not found", http.StatusNotFound)
+				return
+			}
+			reply(fakeSections[mod.BaseAddress][n])
+		case "/Module/Exports":
+			reply(fakeExports)
+		case "/Module/Imports":
+			reply(fakeImports)
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL).X64dbg()
+}
+
+func TestModule(t *testing.T) {
+	m := fakeModulePlugin(t).Module
+	dll := int(fakeDll.BaseAddress) + 0x1234
+
+	cases := []struct {
+		name      string
+		got, want any
+	}{
+		{"Info", m.Info(MainModule()), fakeMain},
+		{"InfoAt", m.Info(ModuleAt(dll)), fakeDll},
+		{"InfoNamed", m.Info(ModuleNamed("KERNEL32.DLL")), fakeDll},
+		{"SectionList", m.SectionList(ModuleAt(0x401500)), fakeSections[fakeMain.BaseAddress]},
+		{"Section", m.Section(ModuleNamed("a.exe"), 1), fakeSections[fakeMain.BaseAddress][1]},
+		{"Exports", m.Exports(ModuleNamed("kernel32.dll")), fakeExports},
+		{"Imports", m.Imports(MainModule()), fakeImports},
+		{"GetList", m.GetList(), []moduleInfo{fakeMain, fakeDll}},
+
+		{"InfoFromAddr", m.InfoFromAddr(dll), fakeDll},
+		{"InfoFromName", m.InfoFromName("kernel32.dll"), fakeDll},
+		{"BaseFromAddr", m.BaseFromAddr(dll), fakeDll.BaseAddress},
+		{"BaseFromName", m.BaseFromName("kernel32.dll"), fakeDll.BaseAddress},
+		{"SizeFromAddr", m.SizeFromAddr(dll), fakeDll.Size},
+		{"SizeFromName", m.SizeFromName("kernel32.dll"), fakeDll.Size},
+		{"NameFromAddr", m.NameFromAddr(dll), fakeDll.Name},
+		{"PathFromAddr", m.PathFromAddr(dll), fakeDll.Path},
+		{"PathFromName", m.PathFromName("kernel32.dll"), fakeDll.Path},
+		{"EntryFromAddr", m.EntryFromAddr(dll), fakeDll.Entry},
+		{"EntryFromName", m.EntryFromName("kernel32.dll"), fakeDll.Entry},
+		{"SectionCountFromAddr", m.SectionCountFromAddr(dll), HexInt(fakeDll.SectionCount)},
+		{"SectionCountFromName", m.SectionCountFromName("kernel32.dll"), HexInt(fakeDll.SectionCount)},
+		{"SectionFromAddr", m.SectionFromAddr(dll, 0), fakeSections[fakeDll.BaseAddress][0]},
+		{"SectionFromName", m.SectionFromName("kernel32.dll", 0), fakeSections[fakeDll.BaseAddress][0]},
+		{"SectionListFromAddr", m.SectionListFromAddr(dll), fakeSections[fakeDll.BaseAddress]},
+		{"SectionListFromName", m.SectionListFromName("kernel32.dll"), fakeSections[fakeDll.BaseAddress]},
+
+		{"GetMainModuleInfo", m.GetMainModuleInfo(), fakeMain},
+		{"GetMainModuleBase", m.GetMainModuleBase(), fakeMain.BaseAddress},
+		{"GetMainModuleSize", m.GetMainModuleSize(), fakeMain.Size},
+		{"GetMainModuleEntry", m.GetMainModuleEntry(), fakeMain.Entry},
+		{"GetMainModuleSectionCount", m.GetMainModuleSectionCount(), fakeMain.SectionCount},
+		{"GetMainModuleName", m.GetMainModuleName(), fakeMain.Name},
+		{"GetMainModulePath", m.GetMainModulePath(), fakeMain.Path},
+		{"GetMainModuleSectionList", m.GetMainModuleSectionList(), fakeSections[fakeMain.BaseAddress]},
+		{"GetExports", m.GetExports(fakeDll), fakeExports},
+		{"GetImports", m.GetImports(fakeMain), fakeImports},
+	}
+	for _, c := range cases {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s:\n got %+v\nwant %+v", c.name, c.got, c.want)
+		}
+	}
+
+	if _, err := m.TryInfo(ModuleNamed("missing.dll")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing module: %v, want ErrNotFound", err)
+	}
+	if _, err := m.TrySectionFromName("a.exe", 5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing section: %v, want ErrNotFound", err)
+	}
+	// 地址 0 不再退回主模块
+	if _, err := m.TryInfoFromAddr(0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("InfoFromAddr(0): %v, want ErrNotFound", err)
+	}
+	if _, err := m.TryBaseFromAddr(0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("BaseFromAddr(0): %v, want ErrNotFound", err)
+	}
+	if _, err := m.TryGetExports(moduleInfo{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetExports(moduleInfo{}): %v, want ErrNotFound", err)
+	}
+}
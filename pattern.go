@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// DefaultMaxFindResult is the cap of a scan with max 0, the default of x64dbg's setmaxfindresult.
+const DefaultMaxFindResult = 5000
+
+// Pattern/FindAll scans this much per request so results stream in while a large module is scanned
+const patternScanChunk = 4 * 1024 * 1024
+
+// Pattern is a byte signature, Mask selects the bits of Bytes that must match: 0xff for a byte,
+// 0xf0 or 0x0f for a nibble and 0 for a wildcard.
+type Pattern struct {
+	Bytes []byte
+	Mask  []byte
+}
+
+// ParsePattern reads an IDA style signature "48 8B 05 ?? ?? ?? ?? 4? 8B" where ? or ?? is a wildcard byte
+// and 4? or ?B a nibble mask, the x64dbg form without spaces "488B05????????4?8B" works as well.
+func ParsePattern(signature string) (Pattern, error) {
+	var p Pattern
+	for _, token := range strings.Fields(signature) {
+		if token == "?" {
+			token = "??"
+		}
+		if len(token)%2 != 0 {
+			return Pattern{}, fmt.Errorf("x64dbg: pattern %q: odd token %q", signature, token)
+		}
+		for i := 0; i < len(token); i += 2 {
+			var b, mask byte
+			for _, c := range token[i : i+2] {
+				b, mask = b<<4, mask<<4
+				if c == '?' {
+					continue
+				}
+				v, err := strconv.ParseUint(string(c), 16, 8)
+				if err != nil {
+					return Pattern{}, fmt.Errorf("x64dbg: pattern %q: bad digit %q", signature, c)
+				}
+				b, mask = b|byte(v), mask|0xf
+			}
+			p.Bytes = append(p.Bytes, b)
+			p.Mask = append(p.Mask, mask)
+		}
+	}
+	if len(p.Bytes) == 0 {
+		return Pattern{}, fmt.Errorf("x64dbg: pattern %q: empty", signature)
+	}
+	return p, nil
+}
+
+func (p Pattern) Len() int { return len(p.Bytes) }
+
+// Match reports whether b starts with p.
+func (p Pattern) Match(b []byte) bool {
+	if len(b) < len(p.Bytes) {
+		return false
+	}
+	for i, want := range p.Bytes {
+		if b[i]&p.Mask[i] != want {
+			return false
+		}
+	}
+	return true
+}
+
+// String is the IDA form "48 8B ?? 4?", x64dbg takes it as well.
+func (p Pattern) String() string {
+	const digits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i, v := range p.Bytes {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		for _, shift := range []uint{4, 0} {
+			if p.Mask[i]>>shift&0xf == 0 {
+				b.WriteByte('?')
+			} else {
+				b.WriteByte(digits[v>>shift&0xf])
+			}
+		}
+	}
+	return b.String()
+}
+
+// PatternScope is the memory a scan covers, see ScanRange, ScanModule, ScanSection and ScanMemoryMap.
+type PatternScope struct {
+	start, size int
+	module      *ModuleRef
+	section     string
+	memoryMap   bool
+}
+
+func ScanRange(start int, size int) PatternScope { return PatternScope{start: start, size: size} }
+func ScanModule(ref ModuleRef) PatternScope      { return PatternScope{module: &ref} }
+
+// ScanSection scans one section of a module by name, ".text" for example.
+func ScanSection(ref ModuleRef, section string) PatternScope {
+	return PatternScope{module: &ref, section: section}
+}
+
+// ScanMemoryMap scans every committed readable region except guard pages.
+func ScanMemoryMap() PatternScope { return PatternScope{memoryMap: true} }
+
+type scanRange struct{ start, size int }
+
+func (s PatternScope) ranges(ctx context.Context, c *Client) ([]scanRange, error) {
+	switch {
+	case s.module != nil && s.section != "":
+		sections, err := module{c}.SectionListContext(ctx, *s.module)
+		if err != nil {
+			return nil, err
+		}
+		for _, section := range sections {
+			if section.Name == s.section {
+				return []scanRange{{int(section.Address), int(section.Size)}}, nil
+			}
+		}
+		return nil, fmt.Errorf("x64dbg: section %s: %w", s.section, ErrNotFound)
+	case s.module != nil:
+		info, err := module{c}.InfoContext(ctx, *s.module)
+		if err != nil {
+			return nil, err
+		}
+		return []scanRange{{int(info.BaseAddress), int(info.Size)}}, nil
+	case !s.memoryMap:
+		if s.size <= 0 {
+			return nil, nil
+		}
+		return []scanRange{{s.start, s.size}}, nil
+	}
+	regions, err := memory{c}.MapContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ranges []scanRange
+	for _, r := range regions {
+		if r.State == PageCommit && r.Protect.Readable() && !r.Protect.Guard() {
+			ranges = append(ranges, scanRange{int(r.BaseAddress), int(r.Size)})
+		}
+	}
+	return ranges, nil
+}
+
+// Scan yields the address of every match of signature in scope, at most max of them, 0 means
+// DefaultMaxFindResult. An error is yielded once and ends the scan, as does breaking the loop.
+func (p pattern) Scan(ctx context.Context, scope PatternScope, signature string, max int) iter.Seq2[HexInt, error] {
+	return func(yield func(HexInt, error) bool) {
+		sig, err := ParsePattern(signature)
+		if err != nil {
+			yield(0, err)
+			return
+		}
+		ranges, err := scope.ranges(ctx, p.client)
+		if err != nil {
+			yield(0, err)
+			return
+		}
+		if max <= 0 {
+			max = DefaultMaxFindResult
+		}
+		for _, r := range ranges {
+			for chunk := r.start; chunk < r.start+r.size; chunk += patternScanChunk {
+				// 块之间重叠 len-1 字节，跨块的匹配也能找到
+				end := chunk + patternScanChunk
+				size := min(end+sig.Len()-1, r.start+r.size) - chunk
+				found, err := tryRequest[[]HexInt](p.client, ctx, "Pattern/FindAll", map[string]string{
+					"start": fmt.Sprintf("0x%x", chunk), "size": strconv.Itoa(size), "pattern": sig.String(), "max": strconv.Itoa(max),
+				})
+				if err != nil {
+					yield(0, err)
+					return
+				}
+				for _, address := range found {
+					if int(address) >= end {
+						break
+					}
+					if !yield(address, nil) {
+						return
+					}
+					if max--; max == 0 {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// FindAll collects Scan.
+func (p pattern) FindAll(scope PatternScope, signature string, max int) []HexInt {
+	return must(p.TryFindAll(scope, signature, max))
+}
+func (p pattern) TryFindAll(scope PatternScope, signature string, max int) ([]HexInt, error) {
+	return p.FindAllContext(context.Background(), scope, signature, max)
+}
+func (p pattern) FindAllContext(ctx context.Context, scope PatternScope, signature string, max int) ([]HexInt, error) {
+	var all []HexInt
+	for address, err := range p.Scan(ctx, scope, signature, max) {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, address)
+	}
+	return all, nil
+}
+
+// SetMaxFindResult sets the cap of x64dbg's own find, findall and findallmem commands.
+func (p pattern) SetMaxFindResult(max int) { mylog.Check(p.TrySetMaxFindResult(max)) }
+func (p pattern) TrySetMaxFindResult(max int) error {
+	return p.SetMaxFindResultContext(context.Background(), max)
+}
+func (p pattern) SetMaxFindResultContext(ctx context.Context, max int) error {
+	if max <= 0 {
+		return errors.New("x64dbg: setmaxfindresult: max must be positive")
+	}
+	_, err := command{p.client}.ExecContext(ctx, fmt.Sprintf("setmaxfindresult 0x%x", max))
+	return err
+}
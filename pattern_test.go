@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	for _, tt := range []struct {
+		signature  string
+		bytes      []byte
+		mask       []byte
+		normalized string
+	}{
+		{"48 8B 05 ?? ?? ?? ??", []byte{0x48, 0x8b, 5, 0, 0, 0, 0}, []byte{0xff, 0xff, 0xff, 0, 0, 0, 0}, "48 8B 05 ?? ?? ?? ??"},
+		{"e8 ? ? ? ? c3", []byte{0xe8, 0, 0, 0, 0, 0xc3}, []byte{0xff, 0, 0, 0, 0, 0xff}, "E8 ?? ?? ?? ?? C3"},
+		{"4? ?B", []byte{0x40, 0x0b}, []byte{0xf0, 0x0f}, "4? ?B"},
+		{"488B??4?", []byte{0x48, 0x8b, 0, 0x40}, []byte{0xff, 0xff, 0, 0xf0}, "48 8B ?? 4?"},
+	} {
+		p, err := ParsePattern(tt.signature)
+		if err != nil {
+			t.Errorf("%q: %v", tt.signature, err)
+			continue
+		}
+		if !slices.Equal(p.Bytes, tt.bytes) || !slices.Equal(p.Mask, tt.mask) || p.String() != tt.normalized {
+			t.Errorf("%q: % x / % x %q", tt.signature, p.Bytes, p.Mask, p)
+		}
+	}
+	for _, bad := range []string{"", "  ", "48 8", "4G", "48 8B ???"} {
+		if _, err := ParsePattern(bad); err == nil {
+			t.Errorf("%q parsed", bad)
+		}
+	}
+
+	p, _ := ParsePattern("4? ?B C3")
+	for _, tt := range []struct {
+		b    []byte
+		want bool
+	}{
+		{[]byte{0x48, 0x8b, 0xc3}, true},
+		{[]byte{0x4f, 0x0b, 0xc3, 0x90}, true},
+		{[]byte{0x58, 0x8b, 0xc3}, false},
+		{[]byte{0x48, 0x8c, 0xc3}, false},
+		{[]byte{0x48, 0x8b}, false},
+	} {
+		if got := p.Match(tt.b); got != tt.want {
+			t.Errorf("Match(% x) = %v", tt.b, got)
+		}
+	}
+}
+
+// fakePatternPlugin answers Pattern/FindAll over image mapped at base, with one module of two
+// sections covering it and a memory map in which only the first half is readable.
+type fakePatternPlugin struct {
+	base     int
+	image    []byte
+	requests []string
+}
+
+func (f *fakePatternPlugin) client(t *testing.T) x64dbg {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		half := len(f.image) / 2
+		switch r.URL.Path {
+		case "/Pattern/FindAll":
+			start, _ := strconv.ParseUint(strings.TrimPrefix(q.Get("start"), "0x"), 16, 64)
+			size, _ := strconv.Atoi(q.Get("size"))
+			max, _ := strconv.Atoi(q.Get("max"))
+			f.requests = append(f.requests, fmt.Sprintf("0x%x+0x%x", start, size))
+			p, err := ParsePattern(q.Get("pattern"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			hits := []HexInt{}
+			data := f.image[int(start)-f.base : int(start)-f.base+size]
+			for i := range data {
+				if len(hits) < max && p.Match(data[i:]) {
+					hits = append(hits, HexInt(int(start)+i))
+				}
+			}
+			json.NewEncoder(w).Encode(hits)
+		case "/Module/Info":
+			fmt.Fprintf(w, `{"name":"a.exe","base":"0x%x","size":"0x%x","entry":"0x0","sectionCount":2,"path":"a.exe"}`, f.base, len(f.image))
+		case "/Module/SectionList":
+			fmt.Fprintf(w, `[{"name":".text","address":"0x%x","size":"0x%x"},{"name":".data","address":"0x%x","size":"0x%x"}]`, f.base, half, f.base+half, half)
+		case "/Memory/Map":
+			fmt.Fprintf(w, `[{"base":"0x%x","size":"0x%x","state":4096,"protect":32},{"base":"0x%x","size":"0x%x","state":4096,"protect":1},{"base":"0x%x","size":"0x%x","state":4096,"protect":260}]`,
+				f.base, half, f.base+half, half/2, f.base+half+half/2, half/2)
+		case "/ExecCommand":
+			w.Write([]byte("Command executed successfully (no output captured)"))
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL).X64dbg()
+}
+
+func TestPatternScan(t *testing.T) {
+	f := &fakePatternPlugin{base: 0x10000000, image: make([]byte, 2*patternScanChunk+0x1000)}
+	p := f.client(t).Pattern
+	signature := "E8 ?? ?? ?? ?? C3"
+	var want []HexInt
+	// 跨第一个块边界的一处，和紧跟在块边界之后的一处
+	for _, offset := range []int{0x10, 0x2000, patternScanChunk - 3, patternScanChunk + 0x10, 2*patternScanChunk + 0x20} {
+		copy(f.image[offset:], []byte{0xe8, 1, 2, 3, 4, 0xc3})
+		want = append(want, HexInt(f.base+offset))
+	}
+
+	got := p.FindAll(ScanModule(ModuleNamed("a.exe")), signature, 0)
+	if !slices.Equal(got, want) {
+		t.Errorf("module: %x, want %x", got, want)
+	}
+	if len(f.requests) != 3 || f.requests[0] != fmt.Sprintf("0x%x+0x%x", f.base, patternScanChunk+5) {
+		t.Errorf("requests: %v", f.requests)
+	}
+
+	if got := p.FindAll(ScanModule(ModuleNamed("a.exe")), signature, 3); !slices.Equal(got, want[:3]) {
+		t.Errorf("max 3: %x", got)
+	}
+	half := HexInt(f.base + len(f.image)/2)
+	text := slices.DeleteFunc(slices.Clone(want), func(a HexInt) bool { return a >= half })
+	if got := p.FindAll(ScanSection(ModuleNamed("a.exe"), ".data"), signature, 0); !slices.Equal(got, want[len(text):]) {
+		t.Errorf(".data: %x, want %x", got, want[len(text):])
+	}
+	if got := p.FindAll(ScanRange(f.base+0x1000, 0x1006), signature, 0); !slices.Equal(got, want[1:2]) {
+		t.Errorf("range: %x", got)
+	}
+	// only the first half is committed, readable and not a guard page
+	if got := p.FindAll(ScanMemoryMap(), signature, 0); !slices.Equal(got, text) {
+		t.Errorf("memory map: %x", got)
+	}
+
+	f.requests = nil
+	for address := range p.Scan(t.Context(), ScanModule(ModuleNamed("a.exe")), signature, 0) {
+		if address != want[0] {
+			t.Errorf("first match 0x%x", address)
+		}
+		break
+	}
+	if len(f.requests) != 1 {
+		t.Errorf("scan went on after break: %v", f.requests)
+	}
+
+	if _, err := p.TryFindAll(ScanSection(ModuleNamed("a.exe"), ".rsrc"), signature, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing section: %v, want ErrNotFound", err)
+	}
+	if _, err := p.TryFindAll(ScanMemoryMap(), "E8 ?", 0); err != nil {
+		t.Errorf("single ? wildcard: %v", err)
+	}
+	if _, err := p.TryFindAll(ScanMemoryMap(), "E8 X", 0); err == nil {
+		t.Error("bad signature accepted")
+	}
+	if err := p.TrySetMaxFindResult(0); err == nil {
+		t.Error("SetMaxFindResult(0) accepted")
+	}
+	p.SetMaxFindResult(100)
+}
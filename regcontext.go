@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Vector128, Vector256 and Vector512 are XMM, YMM and ZMM registers as their memory image, lowest byte first.
+type (
+	Vector128 [16]byte
+	Vector256 [32]byte
+	Vector512 [64]byte
+)
+
+// Float80 is an x87 register, the 80 bit extended precision image, lowest byte first.
+type Float80 [10]byte
+
+// Segments are the segment selectors.
+type Segments struct {
+	GS, FS, ES, DS, CS, SS uint16
+}
+
+// X87 is the x87 FPU state, ST[0] is the top of the register stack.
+type X87 struct {
+	ControlWord uint16
+	StatusWord  uint16
+	TagWord     uint16
+	ST          [8]Float80
+}
+
+// Context is a whole register set read in one round trip, a Context64 from x64dbg or a Context32
+// from x32dbg, type switch on it for the named registers.
+type Context interface {
+	// Bits is 64 or 32.
+	Bits() int
+	// Registers lists every register in the order of x64dbg's register view.
+	Registers() []RegisterValue
+	// Diff lists the registers whose value in other is not the one in this context, call it on the
+	// context before a step with the one after.
+	Diff(other Context) []RegisterChange
+}
+
+// Context64 is the register context of a 64 bit debuggee. AVX512 tells whether ZMM and K were read,
+// it is false when the cpu or x64dbg has no AVX-512. The low 16 ZMM registers share their low half with YMM.
+type Context64 struct {
+	RAX, RBX, RCX, RDX, RSI, RDI, RBP, RSP uint64
+	R8, R9, R10, R11, R12, R13, R14, R15   uint64
+	RIP                                    uint64
+	RFLAGS                                 uint64
+	Segments
+	DR0, DR1, DR2, DR3, DR6, DR7 uint64
+	X87                          X87
+	MXCSR                        uint32
+	XMM                          [16]Vector128
+	YMM                          [16]Vector256
+	AVX512                       bool
+	ZMM                          [32]Vector512
+	K                            [8]uint64
+}
+
+// Context32 is the register context of a 32 bit debuggee.
+type Context32 struct {
+	EAX, EBX, ECX, EDX, ESI, EDI, EBP, ESP uint32
+	EIP                                    uint32
+	EFLAGS                                 uint32
+	Segments
+	DR0, DR1, DR2, DR3, DR6, DR7 uint32
+	X87                          X87
+	MXCSR                        uint32
+	XMM                          [8]Vector128
+	YMM                          [8]Vector256
+	AVX512                       bool
+	ZMM                          [8]Vector512
+	K                            [8]uint64
+}
+
+// RegisterBytes is a register value as its memory image, lowest byte first.
+type RegisterBytes []byte
+
+// String prints the value the way the register view does, most significant digit first: 0x246 for
+// scalars, all the digits for vectors and x87 registers.
+func (b RegisterBytes) String() string {
+	if len(b) <= 8 {
+		var v [8]byte
+		copy(v[:], b)
+		return "0x" + strconv.FormatUint(binary.LittleEndian.Uint64(v[:]), 16)
+	}
+	reversed := slices.Clone(b)
+	slices.Reverse(reversed)
+	return hex.EncodeToString(reversed)
+}
+
+func (b RegisterBytes) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(b.String())), nil
+}
+
+type RegisterValue struct {
+	Name  string        `json:"name"`
+	Value RegisterBytes `json:"value"`
+}
+
+func (r RegisterValue) String() string { return r.Name + " " + r.Value.String() }
+
+// RegisterChange is a register Diff found, Old is nil for a register the first context does not have
+// and New for one the second does not have.
+type RegisterChange struct {
+	Name string        `json:"name"`
+	Old  RegisterBytes `json:"old"`
+	New  RegisterBytes `json:"new"`
+}
+
+func (r RegisterChange) String() string {
+	before, after := "-", "-"
+	if r.Old != nil {
+		before = r.Old.String()
+	}
+	if r.New != nil {
+		after = r.New.String()
+	}
+	return r.Name + " " + before + " -> " + after
+}
+
+func (c Context64) Bits() int { return 64 }
+func (c Context32) Bits() int { return 32 }
+
+func (c Context64) Registers() []RegisterValue {
+	var list registerList
+	list.add("RAX", c.RAX)
+	list.add("RBX", c.RBX)
+	list.add("RCX", c.RCX)
+	list.add("RDX", c.RDX)
+	list.add("RBP", c.RBP)
+	list.add("RSP", c.RSP)
+	list.add("RSI", c.RSI)
+	list.add("RDI", c.RDI)
+	list.add("R8", c.R8)
+	list.add("R9", c.R9)
+	list.add("R10", c.R10)
+	list.add("R11", c.R11)
+	list.add("R12", c.R12)
+	list.add("R13", c.R13)
+	list.add("R14", c.R14)
+	list.add("R15", c.R15)
+	list.add("RIP", c.RIP)
+	list.add("RFLAGS", c.RFLAGS)
+	list.addDebug(c.DR0, c.DR1, c.DR2, c.DR3, c.DR6, c.DR7)
+	list.addExtended(c.Segments, c.X87, c.MXCSR, c.XMM[:], c.YMM[:], c.AVX512, c.ZMM[:], c.K[:])
+	return list
+}
+
+func (c Context32) Registers() []RegisterValue {
+	var list registerList
+	list.add("EAX", c.EAX)
+	list.add("EBX", c.EBX)
+	list.add("ECX", c.ECX)
+	list.add("EDX", c.EDX)
+	list.add("EBP", c.EBP)
+	list.add("ESP", c.ESP)
+	list.add("ESI", c.ESI)
+	list.add("EDI", c.EDI)
+	list.add("EIP", c.EIP)
+	list.add("EFLAGS", c.EFLAGS)
+	list.addDebug(c.DR0, c.DR1, c.DR2, c.DR3, c.DR6, c.DR7)
+	list.addExtended(c.Segments, c.X87, c.MXCSR, c.XMM[:], c.YMM[:], c.AVX512, c.ZMM[:], c.K[:])
+	return list
+}
+
+func (c Context64) Diff(other Context) []RegisterChange {
+	return diffRegisters(c.Registers(), other.Registers())
+}
+
+func (c Context32) Diff(other Context) []RegisterChange {
+	return diffRegisters(c.Registers(), other.Registers())
+}
+
+type registerList []RegisterValue
+
+// add appends value, a fixed size value, as its little endian image.
+func (l *registerList) add(name string, value any) {
+	b, err := binary.Append(nil, binary.LittleEndian, value)
+	if err != nil {
+		panic(err) // 只会传定长类型
+	}
+	*l = append(*l, RegisterValue{Name: name, Value: b})
+}
+
+func (l *registerList) addDebug(dr0, dr1, dr2, dr3, dr6, dr7 any) {
+	l.add("DR0", dr0)
+	l.add("DR1", dr1)
+	l.add("DR2", dr2)
+	l.add("DR3", dr3)
+	l.add("DR6", dr6)
+	l.add("DR7", dr7)
+}
+
+func (l *registerList) addExtended(seg Segments, x87 X87, mxcsr uint32, xmm []Vector128, ymm []Vector256, avx512 bool, zmm []Vector512, k []uint64) {
+	l.add("GS", seg.GS)
+	l.add("FS", seg.FS)
+	l.add("ES", seg.ES)
+	l.add("DS", seg.DS)
+	l.add("CS", seg.CS)
+	l.add("SS", seg.SS)
+	for i, st := range x87.ST {
+		l.add(fmt.Sprintf("ST%d", i), st)
+	}
+	l.add("X87CW", x87.ControlWord)
+	l.add("X87SW", x87.StatusWord)
+	l.add("X87TW", x87.TagWord)
+	l.add("MXCSR", mxcsr)
+	for i, v := range xmm {
+		l.add(fmt.Sprintf("XMM%d", i), v)
+	}
+	for i, v := range ymm {
+		l.add(fmt.Sprintf("YMM%d", i), v)
+	}
+	if !avx512 {
+		return
+	}
+	for i, v := range zmm {
+		l.add(fmt.Sprintf("ZMM%d", i), v)
+	}
+	for i, v := range k {
+		l.add(fmt.Sprintf("K%d", i), v)
+	}
+}
+
+func diffRegisters(before, after []RegisterValue) []RegisterChange {
+	var changes []RegisterChange
+	for _, b := range before {
+		i := slices.IndexFunc(after, func(a RegisterValue) bool { return a.Name == b.Name })
+		switch {
+		case i < 0:
+			changes = append(changes, RegisterChange{Name: b.Name, Old: b.Value})
+		case !slices.Equal(b.Value, after[i].Value):
+			changes = append(changes, RegisterChange{Name: b.Name, Old: b.Value, New: after[i].Value})
+		}
+	}
+	for _, a := range after {
+		if !slices.ContainsFunc(before, func(b RegisterValue) bool { return b.Name == a.Name }) {
+			changes = append(changes, RegisterChange{Name: a.Name, New: a.Value})
+		}
+	}
+	return changes
+}
+
+// typed turns the wire form into the Context of its bitness.
+func (w registerContext) typed() (Context, error) {
+	switch w.Bits {
+	case 64:
+		c := Context64{
+			RAX: uint64(w.CAX), RBX: uint64(w.CBX), RCX: uint64(w.CCX), RDX: uint64(w.CDX),
+			RSI: uint64(w.CSI), RDI: uint64(w.CDI), RBP: uint64(w.CBP), RSP: uint64(w.CSP),
+			R8: uint64(w.R8), R9: uint64(w.R9), R10: uint64(w.R10), R11: uint64(w.R11),
+			R12: uint64(w.R12), R13: uint64(w.R13), R14: uint64(w.R14), R15: uint64(w.R15),
+			RIP: uint64(w.CIP), RFLAGS: uint64(w.CFLAGS),
+			DR0: uint64(w.DR0), DR1: uint64(w.DR1), DR2: uint64(w.DR2), DR3: uint64(w.DR3), DR6: uint64(w.DR6), DR7: uint64(w.DR7),
+			MXCSR: uint32(w.MXCSR),
+		}
+		c.AVX512 = w.fill(&c.Segments, &c.X87, c.XMM[:], c.YMM[:], c.ZMM[:], c.K[:])
+		return c, nil
+	case 32:
+		c := Context32{
+			EAX: uint32(w.CAX), EBX: uint32(w.CBX), ECX: uint32(w.CCX), EDX: uint32(w.CDX),
+			ESI: uint32(w.CSI), EDI: uint32(w.CDI), EBP: uint32(w.CBP), ESP: uint32(w.CSP),
+			EIP: uint32(w.CIP), EFLAGS: uint32(w.CFLAGS),
+			DR0: uint32(w.DR0), DR1: uint32(w.DR1), DR2: uint32(w.DR2), DR3: uint32(w.DR3), DR6: uint32(w.DR6), DR7: uint32(w.DR7),
+			MXCSR: uint32(w.MXCSR),
+		}
+		c.AVX512 = w.fill(&c.Segments, &c.X87, c.XMM[:], c.YMM[:], c.ZMM[:], c.K[:])
+		return c, nil
+	}
+	return nil, fmt.Errorf("x64dbg: Register/Context: unknown bitness %d", w.Bits)
+}
+
+// fill copies what both bitnesses share and tells whether the AVX-512 state was there.
+func (w registerContext) fill(seg *Segments, x87 *X87, xmm []Vector128, ymm []Vector256, zmm []Vector512, k []uint64) bool {
+	*seg = Segments{GS: uint16(w.GS), FS: uint16(w.FS), ES: uint16(w.ES), DS: uint16(w.DS), CS: uint16(w.CS), SS: uint16(w.SS)}
+	x87.ControlWord = uint16(w.X87.Control)
+	x87.StatusWord = uint16(w.X87.Status)
+	x87.TagWord = uint16(w.X87.Tag)
+	for i := range min(len(x87.ST), len(w.X87.ST)) {
+		copy(x87.ST[i][:], w.X87.ST[i])
+	}
+	for i := range min(len(xmm), len(w.XMM)) {
+		copy(xmm[i][:], w.XMM[i])
+	}
+	for i := range min(len(ymm), len(w.YMM)) {
+		copy(ymm[i][:], w.YMM[i])
+	}
+	if !w.AVX512 {
+		return false
+	}
+	for i := range min(len(zmm), len(w.ZMM)) {
+		copy(zmm[i][:], w.ZMM[i])
+	}
+	for i := range min(len(k), len(w.K)) {
+		k[i] = uint64(w.K[i])
+	}
+	return true
+}
+
+func snapshot(c *Client, ctx context.Context, params map[string]string) (Context, error) {
+	w, err := tryRequest[registerContext](c, ctx, "Register/Context", params)
+	if err != nil {
+		return nil, err
+	}
+	return w.typed()
+}
+
+// Snapshot reads every register of the current thread in one request, where GetRAX and friends
+// take one each. ZMM and K come along when AVX512 of the result is true.
+func (m RegisterManager) Snapshot() Context {
+	return must(m.TrySnapshot())
+}
+func (m RegisterManager) TrySnapshot() (Context, error) {
+	return m.SnapshotContext(context.Background())
+}
+func (m RegisterManager) SnapshotContext(ctx context.Context) (Context, error) {
+	return snapshot(m.client, ctx, nil)
+}
+
+// Snapshot reads every register of thread id, see GetRegister.
+func (t thread) Snapshot(id int) Context {
+	return must(t.TrySnapshot(id))
+}
+func (t thread) TrySnapshot(id int) (Context, error) {
+	return t.SnapshotContext(context.Background(), id)
+}
+func (t thread) SnapshotContext(ctx context.Context, id int) (Context, error) {
+	return snapshot(t.client, ctx, map[string]string{"thread": strconv.Itoa(id)})
+}
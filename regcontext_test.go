@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRegisterSnapshot(t *testing.T) {
+	file := "Register_Context.json"
+	p := &fakeServer{}
+	p.handle("/Register/Context", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := os.ReadFile(filepath.Join("testdata", "plugin", file))
+		w.Write(body)
+	})
+	x := p.client(t).X64dbg()
+
+	c, ok := x.Register.Snapshot().(Context64)
+	if !ok {
+		t.Fatalf("Snapshot of x64dbg is not a Context64")
+	}
+	if c.RIP != 0x7ff6a1b21000 || c.RCX != 0x7ffd3e0a0000 || c.R8 != 0x14fe20 || c.RFLAGS != 0x246 || c.CS != 0x33 ||
+		c.DR0 != 0x7ff6a1b23000 || c.MXCSR != 0x1f80 || c.X87.ControlWord != 0x27f || c.X87.ST[0][9] != 0x3f ||
+		c.XMM[1][3] != 0x3f || c.YMM[0][31] != 0x7f || !c.AVX512 || [32]byte(c.ZMM[0][:32]) != c.YMM[0] || c.ZMM[31][63] != 0xff || c.K[0] != 0xffff {
+		t.Errorf("Context64: %+v", c)
+	}
+	registers := c.Registers()
+	if len(registers) != 18+6+6+8+3+1+16+16+32+8 || registers[0].String() != "RAX 0x1" ||
+		registers[len(registers)-56].String() != "YMM0 7ff0000000000000000000000000000040000000000000003ff0000000000000" ||
+		registers[len(registers)-8].String() != "K0 0xffff" {
+		t.Errorf("Registers: %d %v", len(registers), registers)
+	}
+
+	// 单步之后
+	after := c
+	after.RIP += 4
+	after.RAX = 0
+	after.XMM[15][0] = 1
+	changes := c.Diff(after)
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	want := []string{"RAX 0x1 -> 0x0", "RIP 0x7ff6a1b21000 -> 0x7ff6a1b21004", "XMM15 00000000000000000000000000000000 -> 00000000000000000000000000000001"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff:\n%q\nwant\n%q", got, want)
+	}
+	if changes := c.Diff(c); len(changes) != 0 {
+		t.Errorf("Diff with itself: %v", changes)
+	}
+
+	file = "Register_Context32.json"
+	c32, ok := x.Thread.Snapshot(4242).(Context32)
+	if !ok {
+		t.Fatalf("Snapshot of x32dbg is not a Context32")
+	}
+	if c32.EIP != 0x401000 || c32.ESP != 0x19ff70 || c32.EFLAGS != 0x246 || c32.CS != 0x23 || c32.Bits() != 32 || c32.AVX512 {
+		t.Errorf("Context32: %+v", c32)
+	}
+	// 位数不同时按名字对齐，只在一边的寄存器也算变化
+	changes = c.Diff(c32)
+	if changes[0].Name != "RAX" || changes[0].New != nil || changes[len(changes)-1].Name != "EFLAGS" || changes[len(changes)-1].Old != nil ||
+		slices.ContainsFunc(c32.Registers(), func(r RegisterValue) bool { return r.Name == "ZMM0" }) {
+		t.Errorf("Diff across bitness: %v", changes)
+	}
+	threads := p.sent(func(r fakeRequest) string { return r.Path + " " + r.Query.Get("thread") })
+	if !slices.Equal(threads, []string{"/Register/Context ", "/Register/Context 4242"}) {
+		t.Errorf("threads: %q", threads)
+	}
+}
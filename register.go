@@ -1,540 +1,1138 @@
 package main
 
 import (
-	"cmp"
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+	"github.com/ddkwork/golibrary/std/mylog"
 	"strconv"
 	"strings"
-	"time"
-
-	"github.com/ddkwork/golibrary/std/mylog"
 )
 
 type RegisterEnum int
-type RegisterManager struct{}
+type RegisterManager struct{ client *Client }
 
-func (m RegisterManager) GetDR0() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR0"})
-}
-func (m RegisterManager) SetDR0(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR0", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDR1() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR1"})
-}
-func (m RegisterManager) SetDR1(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR1", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDR2() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR2"})
-}
-func (m RegisterManager) SetDR2(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR2", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDR3() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR3"})
-}
-func (m RegisterManager) SetDR3(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR3", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDR6() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR6"})
-}
-func (m RegisterManager) SetDR6(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR6", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDR7() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "DR7"})
-}
-func (m RegisterManager) SetDR7(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DR7", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEAX() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EAX"})
-}
-func (m RegisterManager) SetEAX(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EAX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetAX() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "AX"})
-}
-func (m RegisterManager) SetAX(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "AX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetAH() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "AH"})
-}
-func (m RegisterManager) SetAH(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "AH", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetAL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "AL"})
-}
-func (m RegisterManager) SetAL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "AL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEBX() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EBX"})
-}
-func (m RegisterManager) SetEBX(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EBX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetBX() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "BX"})
-}
-func (m RegisterManager) SetBX(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "BX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetBH() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "BH"})
-}
-func (m RegisterManager) SetBH(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "BH", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetBL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "BL"})
-}
-func (m RegisterManager) SetBL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "BL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetECX() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "ECX"})
-}
-func (m RegisterManager) SetECX(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "ECX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCX() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "CX"})
-}
-func (m RegisterManager) SetCX(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCH() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "CH"})
-}
-func (m RegisterManager) SetCH(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CH", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "CL"})
-}
-func (m RegisterManager) SetCL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEDX() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EDX"})
-}
-func (m RegisterManager) SetEDX(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EDX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDX() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "DX"})
-}
-func (m RegisterManager) SetDX(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDH() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "DH"})
-}
-func (m RegisterManager) SetDH(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DH", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "DL"})
-}
-func (m RegisterManager) SetDL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEDI() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EDI"})
-}
-func (m RegisterManager) SetEDI(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EDI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDI() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "DI"})
-}
-func (m RegisterManager) SetDI(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetESI() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "ESI"})
-}
-func (m RegisterManager) SetESI(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "ESI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetSI() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "SI"})
-}
-func (m RegisterManager) SetSI(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "SI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEBP() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EBP"})
-}
-func (m RegisterManager) SetEBP(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EBP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetBP() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "BP"})
-}
-func (m RegisterManager) SetBP(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "BP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetESP() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "ESP"})
-}
-func (m RegisterManager) SetESP(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "ESP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetSP() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "SP"})
-}
-func (m RegisterManager) SetSP(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "SP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetEIP() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "EIP"})
-}
-func (m RegisterManager) SetEIP(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "EIP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRAX() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RAX"})
-}
-func (m RegisterManager) SetRAX(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RAX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRBX() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RBX"})
-}
-func (m RegisterManager) SetRBX(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RBX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRCX() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RCX"})
-}
-func (m RegisterManager) SetRCX(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RCX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRDX() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RDX"})
-}
-func (m RegisterManager) SetRDX(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RDX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRSI() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RSI"})
-}
-func (m RegisterManager) SetRSI(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RSI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetSIL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "SIL"})
-}
-func (m RegisterManager) SetSIL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "SIL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRDI() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RDI"})
-}
-func (m RegisterManager) SetRDI(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RDI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetDIL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "DIL"})
-}
-func (m RegisterManager) SetDIL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "DIL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRBP() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RBP"})
-}
-func (m RegisterManager) SetRBP(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RBP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetBPL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "BPL"})
-}
-func (m RegisterManager) SetBPL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "BPL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRSP() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RSP"})
-}
-func (m RegisterManager) SetRSP(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RSP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetSPL() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "SPL"})
-}
-func (m RegisterManager) SetSPL(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "SPL", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetRIP() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "RIP"})
-}
-func (m RegisterManager) SetRIP(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "RIP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR8() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R8"})
-}
-func (m RegisterManager) SetR8(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R8", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR8D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R8D"})
-}
-func (m RegisterManager) SetR8D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R8D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR8W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R8W"})
-}
-func (m RegisterManager) SetR8W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R8W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR8B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R8B"})
-}
-func (m RegisterManager) SetR8B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R8B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR9() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R9"})
-}
-func (m RegisterManager) SetR9(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R9", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR9D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R9D"})
-}
-func (m RegisterManager) SetR9D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R9D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR9W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R9W"})
-}
-func (m RegisterManager) SetR9W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R9W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR9B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R9B"})
-}
-func (m RegisterManager) SetR9B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R9B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR10() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R10"})
-}
-func (m RegisterManager) SetR10(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R10", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR10D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R10D"})
-}
-func (m RegisterManager) SetR10D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R10D", "value": strconv.FormatUint(uint64(v), 16)})
+func (m RegisterManager) GetDR0() uint             { return must(m.TryGetDR0()) }
+func (m RegisterManager) TryGetDR0() (uint, error) { return m.GetDR0Context(context.Background()) }
+func (m RegisterManager) GetDR0Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR0)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR0(v uint) bool { return must(m.TrySetDR0(v)) }
+func (m RegisterManager) TrySetDR0(v uint) (bool, error) {
+	return m.SetDR0Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR0Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR0, uint(v))
+}
+func (m RegisterManager) GetDR1() uint             { return must(m.TryGetDR1()) }
+func (m RegisterManager) TryGetDR1() (uint, error) { return m.GetDR1Context(context.Background()) }
+func (m RegisterManager) GetDR1Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR1)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR1(v uint) bool { return must(m.TrySetDR1(v)) }
+func (m RegisterManager) TrySetDR1(v uint) (bool, error) {
+	return m.SetDR1Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR1Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR1, uint(v))
+}
+func (m RegisterManager) GetDR2() uint             { return must(m.TryGetDR2()) }
+func (m RegisterManager) TryGetDR2() (uint, error) { return m.GetDR2Context(context.Background()) }
+func (m RegisterManager) GetDR2Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR2)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR2(v uint) bool { return must(m.TrySetDR2(v)) }
+func (m RegisterManager) TrySetDR2(v uint) (bool, error) {
+	return m.SetDR2Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR2Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR2, uint(v))
+}
+func (m RegisterManager) GetDR3() uint             { return must(m.TryGetDR3()) }
+func (m RegisterManager) TryGetDR3() (uint, error) { return m.GetDR3Context(context.Background()) }
+func (m RegisterManager) GetDR3Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR3)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR3(v uint) bool { return must(m.TrySetDR3(v)) }
+func (m RegisterManager) TrySetDR3(v uint) (bool, error) {
+	return m.SetDR3Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR3Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR3, uint(v))
+}
+func (m RegisterManager) GetDR6() uint             { return must(m.TryGetDR6()) }
+func (m RegisterManager) TryGetDR6() (uint, error) { return m.GetDR6Context(context.Background()) }
+func (m RegisterManager) GetDR6Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR6)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR6(v uint) bool { return must(m.TrySetDR6(v)) }
+func (m RegisterManager) TrySetDR6(v uint) (bool, error) {
+	return m.SetDR6Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR6Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR6, uint(v))
+}
+func (m RegisterManager) GetDR7() uint             { return must(m.TryGetDR7()) }
+func (m RegisterManager) TryGetDR7() (uint, error) { return m.GetDR7Context(context.Background()) }
+func (m RegisterManager) GetDR7Context(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, DR7)
+	return uint(v), err
+}
+func (m RegisterManager) SetDR7(v uint) bool { return must(m.TrySetDR7(v)) }
+func (m RegisterManager) TrySetDR7(v uint) (bool, error) {
+	return m.SetDR7Context(context.Background(), v)
+}
+func (m RegisterManager) SetDR7Context(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, DR7, uint(v))
+}
+func (m RegisterManager) GetEAX() uint32             { return must(m.TryGetEAX()) }
+func (m RegisterManager) TryGetEAX() (uint32, error) { return m.GetEAXContext(context.Background()) }
+func (m RegisterManager) GetEAXContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EAX)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEAX(v uint32) bool { return must(m.TrySetEAX(v)) }
+func (m RegisterManager) TrySetEAX(v uint32) (bool, error) {
+	return m.SetEAXContext(context.Background(), v)
+}
+func (m RegisterManager) SetEAXContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EAX, uint(v))
+}
+func (m RegisterManager) GetAX() uint16             { return must(m.TryGetAX()) }
+func (m RegisterManager) TryGetAX() (uint16, error) { return m.GetAXContext(context.Background()) }
+func (m RegisterManager) GetAXContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, AX)
+	return uint16(v), err
+}
+func (m RegisterManager) SetAX(v uint16) bool { return must(m.TrySetAX(v)) }
+func (m RegisterManager) TrySetAX(v uint16) (bool, error) {
+	return m.SetAXContext(context.Background(), v)
+}
+func (m RegisterManager) SetAXContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, AX, uint(v))
+}
+func (m RegisterManager) GetAH() uint8             { return must(m.TryGetAH()) }
+func (m RegisterManager) TryGetAH() (uint8, error) { return m.GetAHContext(context.Background()) }
+func (m RegisterManager) GetAHContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, AH)
+	return uint8(v), err
+}
+func (m RegisterManager) SetAH(v uint8) bool { return must(m.TrySetAH(v)) }
+func (m RegisterManager) TrySetAH(v uint8) (bool, error) {
+	return m.SetAHContext(context.Background(), v)
+}
+func (m RegisterManager) SetAHContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, AH, uint(v))
+}
+func (m RegisterManager) GetAL() uint8             { return must(m.TryGetAL()) }
+func (m RegisterManager) TryGetAL() (uint8, error) { return m.GetALContext(context.Background()) }
+func (m RegisterManager) GetALContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, AL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetAL(v uint8) bool { return must(m.TrySetAL(v)) }
+func (m RegisterManager) TrySetAL(v uint8) (bool, error) {
+	return m.SetALContext(context.Background(), v)
+}
+func (m RegisterManager) SetALContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, AL, uint(v))
+}
+func (m RegisterManager) GetEBX() uint32             { return must(m.TryGetEBX()) }
+func (m RegisterManager) TryGetEBX() (uint32, error) { return m.GetEBXContext(context.Background()) }
+func (m RegisterManager) GetEBXContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EBX)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEBX(v uint32) bool { return must(m.TrySetEBX(v)) }
+func (m RegisterManager) TrySetEBX(v uint32) (bool, error) {
+	return m.SetEBXContext(context.Background(), v)
+}
+func (m RegisterManager) SetEBXContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EBX, uint(v))
+}
+func (m RegisterManager) GetBX() uint16             { return must(m.TryGetBX()) }
+func (m RegisterManager) TryGetBX() (uint16, error) { return m.GetBXContext(context.Background()) }
+func (m RegisterManager) GetBXContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, BX)
+	return uint16(v), err
+}
+func (m RegisterManager) SetBX(v uint16) bool { return must(m.TrySetBX(v)) }
+func (m RegisterManager) TrySetBX(v uint16) (bool, error) {
+	return m.SetBXContext(context.Background(), v)
+}
+func (m RegisterManager) SetBXContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, BX, uint(v))
+}
+func (m RegisterManager) GetBH() uint8             { return must(m.TryGetBH()) }
+func (m RegisterManager) TryGetBH() (uint8, error) { return m.GetBHContext(context.Background()) }
+func (m RegisterManager) GetBHContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, BH)
+	return uint8(v), err
+}
+func (m RegisterManager) SetBH(v uint8) bool { return must(m.TrySetBH(v)) }
+func (m RegisterManager) TrySetBH(v uint8) (bool, error) {
+	return m.SetBHContext(context.Background(), v)
+}
+func (m RegisterManager) SetBHContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, BH, uint(v))
+}
+func (m RegisterManager) GetBL() uint8             { return must(m.TryGetBL()) }
+func (m RegisterManager) TryGetBL() (uint8, error) { return m.GetBLContext(context.Background()) }
+func (m RegisterManager) GetBLContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, BL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetBL(v uint8) bool { return must(m.TrySetBL(v)) }
+func (m RegisterManager) TrySetBL(v uint8) (bool, error) {
+	return m.SetBLContext(context.Background(), v)
+}
+func (m RegisterManager) SetBLContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, BL, uint(v))
+}
+func (m RegisterManager) GetECX() uint32             { return must(m.TryGetECX()) }
+func (m RegisterManager) TryGetECX() (uint32, error) { return m.GetECXContext(context.Background()) }
+func (m RegisterManager) GetECXContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, ECX)
+	return uint32(v), err
+}
+func (m RegisterManager) SetECX(v uint32) bool { return must(m.TrySetECX(v)) }
+func (m RegisterManager) TrySetECX(v uint32) (bool, error) {
+	return m.SetECXContext(context.Background(), v)
+}
+func (m RegisterManager) SetECXContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, ECX, uint(v))
+}
+func (m RegisterManager) GetCX() uint16             { return must(m.TryGetCX()) }
+func (m RegisterManager) TryGetCX() (uint16, error) { return m.GetCXContext(context.Background()) }
+func (m RegisterManager) GetCXContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, CX)
+	return uint16(v), err
+}
+func (m RegisterManager) SetCX(v uint16) bool { return must(m.TrySetCX(v)) }
+func (m RegisterManager) TrySetCX(v uint16) (bool, error) {
+	return m.SetCXContext(context.Background(), v)
+}
+func (m RegisterManager) SetCXContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, CX, uint(v))
+}
+func (m RegisterManager) GetCH() uint8             { return must(m.TryGetCH()) }
+func (m RegisterManager) TryGetCH() (uint8, error) { return m.GetCHContext(context.Background()) }
+func (m RegisterManager) GetCHContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, CH)
+	return uint8(v), err
+}
+func (m RegisterManager) SetCH(v uint8) bool { return must(m.TrySetCH(v)) }
+func (m RegisterManager) TrySetCH(v uint8) (bool, error) {
+	return m.SetCHContext(context.Background(), v)
+}
+func (m RegisterManager) SetCHContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, CH, uint(v))
+}
+func (m RegisterManager) GetCL() uint8             { return must(m.TryGetCL()) }
+func (m RegisterManager) TryGetCL() (uint8, error) { return m.GetCLContext(context.Background()) }
+func (m RegisterManager) GetCLContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, CL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetCL(v uint8) bool { return must(m.TrySetCL(v)) }
+func (m RegisterManager) TrySetCL(v uint8) (bool, error) {
+	return m.SetCLContext(context.Background(), v)
+}
+func (m RegisterManager) SetCLContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, CL, uint(v))
+}
+func (m RegisterManager) GetEDX() uint32             { return must(m.TryGetEDX()) }
+func (m RegisterManager) TryGetEDX() (uint32, error) { return m.GetEDXContext(context.Background()) }
+func (m RegisterManager) GetEDXContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EDX)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEDX(v uint32) bool { return must(m.TrySetEDX(v)) }
+func (m RegisterManager) TrySetEDX(v uint32) (bool, error) {
+	return m.SetEDXContext(context.Background(), v)
+}
+func (m RegisterManager) SetEDXContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EDX, uint(v))
+}
+func (m RegisterManager) GetDX() uint16             { return must(m.TryGetDX()) }
+func (m RegisterManager) TryGetDX() (uint16, error) { return m.GetDXContext(context.Background()) }
+func (m RegisterManager) GetDXContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, DX)
+	return uint16(v), err
+}
+func (m RegisterManager) SetDX(v uint16) bool { return must(m.TrySetDX(v)) }
+func (m RegisterManager) TrySetDX(v uint16) (bool, error) {
+	return m.SetDXContext(context.Background(), v)
+}
+func (m RegisterManager) SetDXContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, DX, uint(v))
+}
+func (m RegisterManager) GetDH() uint8             { return must(m.TryGetDH()) }
+func (m RegisterManager) TryGetDH() (uint8, error) { return m.GetDHContext(context.Background()) }
+func (m RegisterManager) GetDHContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, DH)
+	return uint8(v), err
+}
+func (m RegisterManager) SetDH(v uint8) bool { return must(m.TrySetDH(v)) }
+func (m RegisterManager) TrySetDH(v uint8) (bool, error) {
+	return m.SetDHContext(context.Background(), v)
+}
+func (m RegisterManager) SetDHContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, DH, uint(v))
+}
+func (m RegisterManager) GetDL() uint8             { return must(m.TryGetDL()) }
+func (m RegisterManager) TryGetDL() (uint8, error) { return m.GetDLContext(context.Background()) }
+func (m RegisterManager) GetDLContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, DL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetDL(v uint8) bool { return must(m.TrySetDL(v)) }
+func (m RegisterManager) TrySetDL(v uint8) (bool, error) {
+	return m.SetDLContext(context.Background(), v)
+}
+func (m RegisterManager) SetDLContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, DL, uint(v))
+}
+func (m RegisterManager) GetEDI() uint32             { return must(m.TryGetEDI()) }
+func (m RegisterManager) TryGetEDI() (uint32, error) { return m.GetEDIContext(context.Background()) }
+func (m RegisterManager) GetEDIContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EDI)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEDI(v uint32) bool { return must(m.TrySetEDI(v)) }
+func (m RegisterManager) TrySetEDI(v uint32) (bool, error) {
+	return m.SetEDIContext(context.Background(), v)
+}
+func (m RegisterManager) SetEDIContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EDI, uint(v))
+}
+func (m RegisterManager) GetDI() uint16             { return must(m.TryGetDI()) }
+func (m RegisterManager) TryGetDI() (uint16, error) { return m.GetDIContext(context.Background()) }
+func (m RegisterManager) GetDIContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, DI)
+	return uint16(v), err
+}
+func (m RegisterManager) SetDI(v uint16) bool { return must(m.TrySetDI(v)) }
+func (m RegisterManager) TrySetDI(v uint16) (bool, error) {
+	return m.SetDIContext(context.Background(), v)
+}
+func (m RegisterManager) SetDIContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, DI, uint(v))
+}
+func (m RegisterManager) GetESI() uint32             { return must(m.TryGetESI()) }
+func (m RegisterManager) TryGetESI() (uint32, error) { return m.GetESIContext(context.Background()) }
+func (m RegisterManager) GetESIContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, ESI)
+	return uint32(v), err
+}
+func (m RegisterManager) SetESI(v uint32) bool { return must(m.TrySetESI(v)) }
+func (m RegisterManager) TrySetESI(v uint32) (bool, error) {
+	return m.SetESIContext(context.Background(), v)
+}
+func (m RegisterManager) SetESIContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, ESI, uint(v))
+}
+func (m RegisterManager) GetSI() uint16             { return must(m.TryGetSI()) }
+func (m RegisterManager) TryGetSI() (uint16, error) { return m.GetSIContext(context.Background()) }
+func (m RegisterManager) GetSIContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, SI)
+	return uint16(v), err
+}
+func (m RegisterManager) SetSI(v uint16) bool { return must(m.TrySetSI(v)) }
+func (m RegisterManager) TrySetSI(v uint16) (bool, error) {
+	return m.SetSIContext(context.Background(), v)
+}
+func (m RegisterManager) SetSIContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, SI, uint(v))
+}
+func (m RegisterManager) GetEBP() uint32             { return must(m.TryGetEBP()) }
+func (m RegisterManager) TryGetEBP() (uint32, error) { return m.GetEBPContext(context.Background()) }
+func (m RegisterManager) GetEBPContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EBP)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEBP(v uint32) bool { return must(m.TrySetEBP(v)) }
+func (m RegisterManager) TrySetEBP(v uint32) (bool, error) {
+	return m.SetEBPContext(context.Background(), v)
+}
+func (m RegisterManager) SetEBPContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EBP, uint(v))
+}
+func (m RegisterManager) GetBP() uint16             { return must(m.TryGetBP()) }
+func (m RegisterManager) TryGetBP() (uint16, error) { return m.GetBPContext(context.Background()) }
+func (m RegisterManager) GetBPContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, BP)
+	return uint16(v), err
+}
+func (m RegisterManager) SetBP(v uint16) bool { return must(m.TrySetBP(v)) }
+func (m RegisterManager) TrySetBP(v uint16) (bool, error) {
+	return m.SetBPContext(context.Background(), v)
+}
+func (m RegisterManager) SetBPContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, BP, uint(v))
+}
+func (m RegisterManager) GetESP() uint32             { return must(m.TryGetESP()) }
+func (m RegisterManager) TryGetESP() (uint32, error) { return m.GetESPContext(context.Background()) }
+func (m RegisterManager) GetESPContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, ESP)
+	return uint32(v), err
+}
+func (m RegisterManager) SetESP(v uint32) bool { return must(m.TrySetESP(v)) }
+func (m RegisterManager) TrySetESP(v uint32) (bool, error) {
+	return m.SetESPContext(context.Background(), v)
+}
+func (m RegisterManager) SetESPContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, ESP, uint(v))
+}
+func (m RegisterManager) GetSP() uint16             { return must(m.TryGetSP()) }
+func (m RegisterManager) TryGetSP() (uint16, error) { return m.GetSPContext(context.Background()) }
+func (m RegisterManager) GetSPContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, SP)
+	return uint16(v), err
+}
+func (m RegisterManager) SetSP(v uint16) bool { return must(m.TrySetSP(v)) }
+func (m RegisterManager) TrySetSP(v uint16) (bool, error) {
+	return m.SetSPContext(context.Background(), v)
+}
+func (m RegisterManager) SetSPContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, SP, uint(v))
+}
+func (m RegisterManager) GetEIP() uint32             { return must(m.TryGetEIP()) }
+func (m RegisterManager) TryGetEIP() (uint32, error) { return m.GetEIPContext(context.Background()) }
+func (m RegisterManager) GetEIPContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, EIP)
+	return uint32(v), err
+}
+func (m RegisterManager) SetEIP(v uint32) bool { return must(m.TrySetEIP(v)) }
+func (m RegisterManager) TrySetEIP(v uint32) (bool, error) {
+	return m.SetEIPContext(context.Background(), v)
+}
+func (m RegisterManager) SetEIPContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, EIP, uint(v))
+}
+func (m RegisterManager) GetRAX() uint64             { return must(m.TryGetRAX()) }
+func (m RegisterManager) TryGetRAX() (uint64, error) { return m.GetRAXContext(context.Background()) }
+func (m RegisterManager) GetRAXContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RAX)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRAX(v uint64) bool { return must(m.TrySetRAX(v)) }
+func (m RegisterManager) TrySetRAX(v uint64) (bool, error) {
+	return m.SetRAXContext(context.Background(), v)
+}
+func (m RegisterManager) SetRAXContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RAX, uint(v))
+}
+func (m RegisterManager) GetRBX() uint64             { return must(m.TryGetRBX()) }
+func (m RegisterManager) TryGetRBX() (uint64, error) { return m.GetRBXContext(context.Background()) }
+func (m RegisterManager) GetRBXContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RBX)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRBX(v uint64) bool { return must(m.TrySetRBX(v)) }
+func (m RegisterManager) TrySetRBX(v uint64) (bool, error) {
+	return m.SetRBXContext(context.Background(), v)
+}
+func (m RegisterManager) SetRBXContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RBX, uint(v))
+}
+func (m RegisterManager) GetRCX() uint64             { return must(m.TryGetRCX()) }
+func (m RegisterManager) TryGetRCX() (uint64, error) { return m.GetRCXContext(context.Background()) }
+func (m RegisterManager) GetRCXContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RCX)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRCX(v uint64) bool { return must(m.TrySetRCX(v)) }
+func (m RegisterManager) TrySetRCX(v uint64) (bool, error) {
+	return m.SetRCXContext(context.Background(), v)
+}
+func (m RegisterManager) SetRCXContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RCX, uint(v))
+}
+func (m RegisterManager) GetRDX() uint64             { return must(m.TryGetRDX()) }
+func (m RegisterManager) TryGetRDX() (uint64, error) { return m.GetRDXContext(context.Background()) }
+func (m RegisterManager) GetRDXContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RDX)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRDX(v uint64) bool { return must(m.TrySetRDX(v)) }
+func (m RegisterManager) TrySetRDX(v uint64) (bool, error) {
+	return m.SetRDXContext(context.Background(), v)
+}
+func (m RegisterManager) SetRDXContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RDX, uint(v))
+}
+func (m RegisterManager) GetRSI() uint64             { return must(m.TryGetRSI()) }
+func (m RegisterManager) TryGetRSI() (uint64, error) { return m.GetRSIContext(context.Background()) }
+func (m RegisterManager) GetRSIContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RSI)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRSI(v uint64) bool { return must(m.TrySetRSI(v)) }
+func (m RegisterManager) TrySetRSI(v uint64) (bool, error) {
+	return m.SetRSIContext(context.Background(), v)
+}
+func (m RegisterManager) SetRSIContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RSI, uint(v))
+}
+func (m RegisterManager) GetSIL() uint8             { return must(m.TryGetSIL()) }
+func (m RegisterManager) TryGetSIL() (uint8, error) { return m.GetSILContext(context.Background()) }
+func (m RegisterManager) GetSILContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, SIL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetSIL(v uint8) bool { return must(m.TrySetSIL(v)) }
+func (m RegisterManager) TrySetSIL(v uint8) (bool, error) {
+	return m.SetSILContext(context.Background(), v)
+}
+func (m RegisterManager) SetSILContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, SIL, uint(v))
+}
+func (m RegisterManager) GetRDI() uint64             { return must(m.TryGetRDI()) }
+func (m RegisterManager) TryGetRDI() (uint64, error) { return m.GetRDIContext(context.Background()) }
+func (m RegisterManager) GetRDIContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RDI)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRDI(v uint64) bool { return must(m.TrySetRDI(v)) }
+func (m RegisterManager) TrySetRDI(v uint64) (bool, error) {
+	return m.SetRDIContext(context.Background(), v)
+}
+func (m RegisterManager) SetRDIContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RDI, uint(v))
+}
+func (m RegisterManager) GetDIL() uint8             { return must(m.TryGetDIL()) }
+func (m RegisterManager) TryGetDIL() (uint8, error) { return m.GetDILContext(context.Background()) }
+func (m RegisterManager) GetDILContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, DIL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetDIL(v uint8) bool { return must(m.TrySetDIL(v)) }
+func (m RegisterManager) TrySetDIL(v uint8) (bool, error) {
+	return m.SetDILContext(context.Background(), v)
+}
+func (m RegisterManager) SetDILContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, DIL, uint(v))
+}
+func (m RegisterManager) GetRBP() uint64             { return must(m.TryGetRBP()) }
+func (m RegisterManager) TryGetRBP() (uint64, error) { return m.GetRBPContext(context.Background()) }
+func (m RegisterManager) GetRBPContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RBP)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRBP(v uint64) bool { return must(m.TrySetRBP(v)) }
+func (m RegisterManager) TrySetRBP(v uint64) (bool, error) {
+	return m.SetRBPContext(context.Background(), v)
+}
+func (m RegisterManager) SetRBPContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RBP, uint(v))
+}
+func (m RegisterManager) GetBPL() uint8             { return must(m.TryGetBPL()) }
+func (m RegisterManager) TryGetBPL() (uint8, error) { return m.GetBPLContext(context.Background()) }
+func (m RegisterManager) GetBPLContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, BPL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetBPL(v uint8) bool { return must(m.TrySetBPL(v)) }
+func (m RegisterManager) TrySetBPL(v uint8) (bool, error) {
+	return m.SetBPLContext(context.Background(), v)
+}
+func (m RegisterManager) SetBPLContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, BPL, uint(v))
+}
+func (m RegisterManager) GetRSP() uint64             { return must(m.TryGetRSP()) }
+func (m RegisterManager) TryGetRSP() (uint64, error) { return m.GetRSPContext(context.Background()) }
+func (m RegisterManager) GetRSPContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RSP)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRSP(v uint64) bool { return must(m.TrySetRSP(v)) }
+func (m RegisterManager) TrySetRSP(v uint64) (bool, error) {
+	return m.SetRSPContext(context.Background(), v)
+}
+func (m RegisterManager) SetRSPContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RSP, uint(v))
+}
+func (m RegisterManager) GetSPL() uint8             { return must(m.TryGetSPL()) }
+func (m RegisterManager) TryGetSPL() (uint8, error) { return m.GetSPLContext(context.Background()) }
+func (m RegisterManager) GetSPLContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, SPL)
+	return uint8(v), err
+}
+func (m RegisterManager) SetSPL(v uint8) bool { return must(m.TrySetSPL(v)) }
+func (m RegisterManager) TrySetSPL(v uint8) (bool, error) {
+	return m.SetSPLContext(context.Background(), v)
+}
+func (m RegisterManager) SetSPLContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, SPL, uint(v))
+}
+func (m RegisterManager) GetRIP() uint64             { return must(m.TryGetRIP()) }
+func (m RegisterManager) TryGetRIP() (uint64, error) { return m.GetRIPContext(context.Background()) }
+func (m RegisterManager) GetRIPContext(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, RIP)
+	return uint64(v), err
+}
+func (m RegisterManager) SetRIP(v uint64) bool { return must(m.TrySetRIP(v)) }
+func (m RegisterManager) TrySetRIP(v uint64) (bool, error) {
+	return m.SetRIPContext(context.Background(), v)
+}
+func (m RegisterManager) SetRIPContext(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, RIP, uint(v))
+}
+func (m RegisterManager) GetR8() uint64             { return must(m.TryGetR8()) }
+func (m RegisterManager) TryGetR8() (uint64, error) { return m.GetR8Context(context.Background()) }
+func (m RegisterManager) GetR8Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R8)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR8(v uint64) bool { return must(m.TrySetR8(v)) }
+func (m RegisterManager) TrySetR8(v uint64) (bool, error) {
+	return m.SetR8Context(context.Background(), v)
+}
+func (m RegisterManager) SetR8Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R8, uint(v))
+}
+func (m RegisterManager) GetR8D() uint32             { return must(m.TryGetR8D()) }
+func (m RegisterManager) TryGetR8D() (uint32, error) { return m.GetR8DContext(context.Background()) }
+func (m RegisterManager) GetR8DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R8D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR8D(v uint32) bool { return must(m.TrySetR8D(v)) }
+func (m RegisterManager) TrySetR8D(v uint32) (bool, error) {
+	return m.SetR8DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR8DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R8D, uint(v))
+}
+func (m RegisterManager) GetR8W() uint16             { return must(m.TryGetR8W()) }
+func (m RegisterManager) TryGetR8W() (uint16, error) { return m.GetR8WContext(context.Background()) }
+func (m RegisterManager) GetR8WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R8W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR8W(v uint16) bool { return must(m.TrySetR8W(v)) }
+func (m RegisterManager) TrySetR8W(v uint16) (bool, error) {
+	return m.SetR8WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR8WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R8W, uint(v))
+}
+func (m RegisterManager) GetR8B() uint8             { return must(m.TryGetR8B()) }
+func (m RegisterManager) TryGetR8B() (uint8, error) { return m.GetR8BContext(context.Background()) }
+func (m RegisterManager) GetR8BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R8B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR8B(v uint8) bool { return must(m.TrySetR8B(v)) }
+func (m RegisterManager) TrySetR8B(v uint8) (bool, error) {
+	return m.SetR8BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR8BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R8B, uint(v))
+}
+func (m RegisterManager) GetR9() uint64             { return must(m.TryGetR9()) }
+func (m RegisterManager) TryGetR9() (uint64, error) { return m.GetR9Context(context.Background()) }
+func (m RegisterManager) GetR9Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R9)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR9(v uint64) bool { return must(m.TrySetR9(v)) }
+func (m RegisterManager) TrySetR9(v uint64) (bool, error) {
+	return m.SetR9Context(context.Background(), v)
+}
+func (m RegisterManager) SetR9Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R9, uint(v))
+}
+func (m RegisterManager) GetR9D() uint32             { return must(m.TryGetR9D()) }
+func (m RegisterManager) TryGetR9D() (uint32, error) { return m.GetR9DContext(context.Background()) }
+func (m RegisterManager) GetR9DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R9D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR9D(v uint32) bool { return must(m.TrySetR9D(v)) }
+func (m RegisterManager) TrySetR9D(v uint32) (bool, error) {
+	return m.SetR9DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR9DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R9D, uint(v))
+}
+func (m RegisterManager) GetR9W() uint16             { return must(m.TryGetR9W()) }
+func (m RegisterManager) TryGetR9W() (uint16, error) { return m.GetR9WContext(context.Background()) }
+func (m RegisterManager) GetR9WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R9W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR9W(v uint16) bool { return must(m.TrySetR9W(v)) }
+func (m RegisterManager) TrySetR9W(v uint16) (bool, error) {
+	return m.SetR9WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR9WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R9W, uint(v))
+}
+func (m RegisterManager) GetR9B() uint8             { return must(m.TryGetR9B()) }
+func (m RegisterManager) TryGetR9B() (uint8, error) { return m.GetR9BContext(context.Background()) }
+func (m RegisterManager) GetR9BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R9B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR9B(v uint8) bool { return must(m.TrySetR9B(v)) }
+func (m RegisterManager) TrySetR9B(v uint8) (bool, error) {
+	return m.SetR9BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR9BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R9B, uint(v))
+}
+func (m RegisterManager) GetR10() uint64             { return must(m.TryGetR10()) }
+func (m RegisterManager) TryGetR10() (uint64, error) { return m.GetR10Context(context.Background()) }
+func (m RegisterManager) GetR10Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R10)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR10(v uint64) bool { return must(m.TrySetR10(v)) }
+func (m RegisterManager) TrySetR10(v uint64) (bool, error) {
+	return m.SetR10Context(context.Background(), v)
+}
+func (m RegisterManager) SetR10Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R10, uint(v))
+}
+func (m RegisterManager) GetR10D() uint32             { return must(m.TryGetR10D()) }
+func (m RegisterManager) TryGetR10D() (uint32, error) { return m.GetR10DContext(context.Background()) }
+func (m RegisterManager) GetR10DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R10D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR10D(v uint32) bool { return must(m.TrySetR10D(v)) }
+func (m RegisterManager) TrySetR10D(v uint32) (bool, error) {
+	return m.SetR10DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR10DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R10D, uint(v))
+}
+func (m RegisterManager) GetR10W() uint16             { return must(m.TryGetR10W()) }
+func (m RegisterManager) TryGetR10W() (uint16, error) { return m.GetR10WContext(context.Background()) }
+func (m RegisterManager) GetR10WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R10W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR10W(v uint16) bool { return must(m.TrySetR10W(v)) }
+func (m RegisterManager) TrySetR10W(v uint16) (bool, error) {
+	return m.SetR10WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR10WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R10W, uint(v))
+}
+func (m RegisterManager) GetR10B() uint8             { return must(m.TryGetR10B()) }
+func (m RegisterManager) TryGetR10B() (uint8, error) { return m.GetR10BContext(context.Background()) }
+func (m RegisterManager) GetR10BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R10B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR10B(v uint8) bool { return must(m.TrySetR10B(v)) }
+func (m RegisterManager) TrySetR10B(v uint8) (bool, error) {
+	return m.SetR10BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR10BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R10B, uint(v))
+}
+func (m RegisterManager) GetR11() uint64             { return must(m.TryGetR11()) }
+func (m RegisterManager) TryGetR11() (uint64, error) { return m.GetR11Context(context.Background()) }
+func (m RegisterManager) GetR11Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R11)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR11(v uint64) bool { return must(m.TrySetR11(v)) }
+func (m RegisterManager) TrySetR11(v uint64) (bool, error) {
+	return m.SetR11Context(context.Background(), v)
+}
+func (m RegisterManager) SetR11Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R11, uint(v))
+}
+func (m RegisterManager) GetR11D() uint32             { return must(m.TryGetR11D()) }
+func (m RegisterManager) TryGetR11D() (uint32, error) { return m.GetR11DContext(context.Background()) }
+func (m RegisterManager) GetR11DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R11D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR11D(v uint32) bool { return must(m.TrySetR11D(v)) }
+func (m RegisterManager) TrySetR11D(v uint32) (bool, error) {
+	return m.SetR11DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR11DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R11D, uint(v))
+}
+func (m RegisterManager) GetR11W() uint16             { return must(m.TryGetR11W()) }
+func (m RegisterManager) TryGetR11W() (uint16, error) { return m.GetR11WContext(context.Background()) }
+func (m RegisterManager) GetR11WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R11W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR11W(v uint16) bool { return must(m.TrySetR11W(v)) }
+func (m RegisterManager) TrySetR11W(v uint16) (bool, error) {
+	return m.SetR11WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR11WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R11W, uint(v))
+}
+func (m RegisterManager) GetR11B() uint8             { return must(m.TryGetR11B()) }
+func (m RegisterManager) TryGetR11B() (uint8, error) { return m.GetR11BContext(context.Background()) }
+func (m RegisterManager) GetR11BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R11B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR11B(v uint8) bool { return must(m.TrySetR11B(v)) }
+func (m RegisterManager) TrySetR11B(v uint8) (bool, error) {
+	return m.SetR11BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR11BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R11B, uint(v))
+}
+func (m RegisterManager) GetR12() uint64             { return must(m.TryGetR12()) }
+func (m RegisterManager) TryGetR12() (uint64, error) { return m.GetR12Context(context.Background()) }
+func (m RegisterManager) GetR12Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R12)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR12(v uint64) bool { return must(m.TrySetR12(v)) }
+func (m RegisterManager) TrySetR12(v uint64) (bool, error) {
+	return m.SetR12Context(context.Background(), v)
+}
+func (m RegisterManager) SetR12Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R12, uint(v))
+}
+func (m RegisterManager) GetR12D() uint32             { return must(m.TryGetR12D()) }
+func (m RegisterManager) TryGetR12D() (uint32, error) { return m.GetR12DContext(context.Background()) }
+func (m RegisterManager) GetR12DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R12D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR12D(v uint32) bool { return must(m.TrySetR12D(v)) }
+func (m RegisterManager) TrySetR12D(v uint32) (bool, error) {
+	return m.SetR12DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR12DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R12D, uint(v))
+}
+func (m RegisterManager) GetR12W() uint16             { return must(m.TryGetR12W()) }
+func (m RegisterManager) TryGetR12W() (uint16, error) { return m.GetR12WContext(context.Background()) }
+func (m RegisterManager) GetR12WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R12W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR12W(v uint16) bool { return must(m.TrySetR12W(v)) }
+func (m RegisterManager) TrySetR12W(v uint16) (bool, error) {
+	return m.SetR12WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR12WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R12W, uint(v))
+}
+func (m RegisterManager) GetR12B() uint8             { return must(m.TryGetR12B()) }
+func (m RegisterManager) TryGetR12B() (uint8, error) { return m.GetR12BContext(context.Background()) }
+func (m RegisterManager) GetR12BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R12B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR12B(v uint8) bool { return must(m.TrySetR12B(v)) }
+func (m RegisterManager) TrySetR12B(v uint8) (bool, error) {
+	return m.SetR12BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR12BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R12B, uint(v))
+}
+func (m RegisterManager) GetR13() uint64             { return must(m.TryGetR13()) }
+func (m RegisterManager) TryGetR13() (uint64, error) { return m.GetR13Context(context.Background()) }
+func (m RegisterManager) GetR13Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R13)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR13(v uint64) bool { return must(m.TrySetR13(v)) }
+func (m RegisterManager) TrySetR13(v uint64) (bool, error) {
+	return m.SetR13Context(context.Background(), v)
+}
+func (m RegisterManager) SetR13Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R13, uint(v))
+}
+func (m RegisterManager) GetR13D() uint32             { return must(m.TryGetR13D()) }
+func (m RegisterManager) TryGetR13D() (uint32, error) { return m.GetR13DContext(context.Background()) }
+func (m RegisterManager) GetR13DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R13D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR13D(v uint32) bool { return must(m.TrySetR13D(v)) }
+func (m RegisterManager) TrySetR13D(v uint32) (bool, error) {
+	return m.SetR13DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR13DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R13D, uint(v))
+}
+func (m RegisterManager) GetR13W() uint16             { return must(m.TryGetR13W()) }
+func (m RegisterManager) TryGetR13W() (uint16, error) { return m.GetR13WContext(context.Background()) }
+func (m RegisterManager) GetR13WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R13W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR13W(v uint16) bool { return must(m.TrySetR13W(v)) }
+func (m RegisterManager) TrySetR13W(v uint16) (bool, error) {
+	return m.SetR13WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR13WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R13W, uint(v))
+}
+func (m RegisterManager) GetR13B() uint8             { return must(m.TryGetR13B()) }
+func (m RegisterManager) TryGetR13B() (uint8, error) { return m.GetR13BContext(context.Background()) }
+func (m RegisterManager) GetR13BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R13B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR13B(v uint8) bool { return must(m.TrySetR13B(v)) }
+func (m RegisterManager) TrySetR13B(v uint8) (bool, error) {
+	return m.SetR13BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR13BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R13B, uint(v))
+}
+func (m RegisterManager) GetR14() uint64             { return must(m.TryGetR14()) }
+func (m RegisterManager) TryGetR14() (uint64, error) { return m.GetR14Context(context.Background()) }
+func (m RegisterManager) GetR14Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R14)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR14(v uint64) bool { return must(m.TrySetR14(v)) }
+func (m RegisterManager) TrySetR14(v uint64) (bool, error) {
+	return m.SetR14Context(context.Background(), v)
+}
+func (m RegisterManager) SetR14Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R14, uint(v))
+}
+func (m RegisterManager) GetR14D() uint32             { return must(m.TryGetR14D()) }
+func (m RegisterManager) TryGetR14D() (uint32, error) { return m.GetR14DContext(context.Background()) }
+func (m RegisterManager) GetR14DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R14D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR14D(v uint32) bool { return must(m.TrySetR14D(v)) }
+func (m RegisterManager) TrySetR14D(v uint32) (bool, error) {
+	return m.SetR14DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR14DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R14D, uint(v))
+}
+func (m RegisterManager) GetR14W() uint16             { return must(m.TryGetR14W()) }
+func (m RegisterManager) TryGetR14W() (uint16, error) { return m.GetR14WContext(context.Background()) }
+func (m RegisterManager) GetR14WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R14W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR14W(v uint16) bool { return must(m.TrySetR14W(v)) }
+func (m RegisterManager) TrySetR14W(v uint16) (bool, error) {
+	return m.SetR14WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR14WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R14W, uint(v))
+}
+func (m RegisterManager) GetR14B() uint8             { return must(m.TryGetR14B()) }
+func (m RegisterManager) TryGetR14B() (uint8, error) { return m.GetR14BContext(context.Background()) }
+func (m RegisterManager) GetR14BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R14B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR14B(v uint8) bool { return must(m.TrySetR14B(v)) }
+func (m RegisterManager) TrySetR14B(v uint8) (bool, error) {
+	return m.SetR14BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR14BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R14B, uint(v))
+}
+func (m RegisterManager) GetR15() uint64             { return must(m.TryGetR15()) }
+func (m RegisterManager) TryGetR15() (uint64, error) { return m.GetR15Context(context.Background()) }
+func (m RegisterManager) GetR15Context(ctx context.Context) (uint64, error) {
+	v, err := m.GetContext(ctx, R15)
+	return uint64(v), err
+}
+func (m RegisterManager) SetR15(v uint64) bool { return must(m.TrySetR15(v)) }
+func (m RegisterManager) TrySetR15(v uint64) (bool, error) {
+	return m.SetR15Context(context.Background(), v)
+}
+func (m RegisterManager) SetR15Context(ctx context.Context, v uint64) (bool, error) {
+	return m.SetContext(ctx, R15, uint(v))
+}
+func (m RegisterManager) GetR15D() uint32             { return must(m.TryGetR15D()) }
+func (m RegisterManager) TryGetR15D() (uint32, error) { return m.GetR15DContext(context.Background()) }
+func (m RegisterManager) GetR15DContext(ctx context.Context) (uint32, error) {
+	v, err := m.GetContext(ctx, R15D)
+	return uint32(v), err
+}
+func (m RegisterManager) SetR15D(v uint32) bool { return must(m.TrySetR15D(v)) }
+func (m RegisterManager) TrySetR15D(v uint32) (bool, error) {
+	return m.SetR15DContext(context.Background(), v)
+}
+func (m RegisterManager) SetR15DContext(ctx context.Context, v uint32) (bool, error) {
+	return m.SetContext(ctx, R15D, uint(v))
+}
+func (m RegisterManager) GetR15W() uint16             { return must(m.TryGetR15W()) }
+func (m RegisterManager) TryGetR15W() (uint16, error) { return m.GetR15WContext(context.Background()) }
+func (m RegisterManager) GetR15WContext(ctx context.Context) (uint16, error) {
+	v, err := m.GetContext(ctx, R15W)
+	return uint16(v), err
+}
+func (m RegisterManager) SetR15W(v uint16) bool { return must(m.TrySetR15W(v)) }
+func (m RegisterManager) TrySetR15W(v uint16) (bool, error) {
+	return m.SetR15WContext(context.Background(), v)
+}
+func (m RegisterManager) SetR15WContext(ctx context.Context, v uint16) (bool, error) {
+	return m.SetContext(ctx, R15W, uint(v))
+}
+func (m RegisterManager) GetR15B() uint8             { return must(m.TryGetR15B()) }
+func (m RegisterManager) TryGetR15B() (uint8, error) { return m.GetR15BContext(context.Background()) }
+func (m RegisterManager) GetR15BContext(ctx context.Context) (uint8, error) {
+	v, err := m.GetContext(ctx, R15B)
+	return uint8(v), err
+}
+func (m RegisterManager) SetR15B(v uint8) bool { return must(m.TrySetR15B(v)) }
+func (m RegisterManager) TrySetR15B(v uint8) (bool, error) {
+	return m.SetR15BContext(context.Background(), v)
+}
+func (m RegisterManager) SetR15BContext(ctx context.Context, v uint8) (bool, error) {
+	return m.SetContext(ctx, R15B, uint(v))
+}
+func (m RegisterManager) GetCIP() uint             { return must(m.TryGetCIP()) }
+func (m RegisterManager) TryGetCIP() (uint, error) { return m.GetCIPContext(context.Background()) }
+func (m RegisterManager) GetCIPContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CIP)
+	return uint(v), err
+}
+func (m RegisterManager) SetCIP(v uint) bool { return must(m.TrySetCIP(v)) }
+func (m RegisterManager) TrySetCIP(v uint) (bool, error) {
+	return m.SetCIPContext(context.Background(), v)
+}
+func (m RegisterManager) SetCIPContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CIP, uint(v))
+}
+func (m RegisterManager) GetCSP() uint             { return must(m.TryGetCSP()) }
+func (m RegisterManager) TryGetCSP() (uint, error) { return m.GetCSPContext(context.Background()) }
+func (m RegisterManager) GetCSPContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CSP)
+	return uint(v), err
+}
+func (m RegisterManager) SetCSP(v uint) bool { return must(m.TrySetCSP(v)) }
+func (m RegisterManager) TrySetCSP(v uint) (bool, error) {
+	return m.SetCSPContext(context.Background(), v)
+}
+func (m RegisterManager) SetCSPContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CSP, uint(v))
+}
+func (m RegisterManager) GetCAX() uint             { return must(m.TryGetCAX()) }
+func (m RegisterManager) TryGetCAX() (uint, error) { return m.GetCAXContext(context.Background()) }
+func (m RegisterManager) GetCAXContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CAX)
+	return uint(v), err
+}
+func (m RegisterManager) SetCAX(v uint) bool { return must(m.TrySetCAX(v)) }
+func (m RegisterManager) TrySetCAX(v uint) (bool, error) {
+	return m.SetCAXContext(context.Background(), v)
+}
+func (m RegisterManager) SetCAXContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CAX, uint(v))
+}
+func (m RegisterManager) GetCBX() uint             { return must(m.TryGetCBX()) }
+func (m RegisterManager) TryGetCBX() (uint, error) { return m.GetCBXContext(context.Background()) }
+func (m RegisterManager) GetCBXContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CBX)
+	return uint(v), err
+}
+func (m RegisterManager) SetCBX(v uint) bool { return must(m.TrySetCBX(v)) }
+func (m RegisterManager) TrySetCBX(v uint) (bool, error) {
+	return m.SetCBXContext(context.Background(), v)
+}
+func (m RegisterManager) SetCBXContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CBX, uint(v))
+}
+func (m RegisterManager) GetCCX() uint             { return must(m.TryGetCCX()) }
+func (m RegisterManager) TryGetCCX() (uint, error) { return m.GetCCXContext(context.Background()) }
+func (m RegisterManager) GetCCXContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CCX)
+	return uint(v), err
+}
+func (m RegisterManager) SetCCX(v uint) bool { return must(m.TrySetCCX(v)) }
+func (m RegisterManager) TrySetCCX(v uint) (bool, error) {
+	return m.SetCCXContext(context.Background(), v)
+}
+func (m RegisterManager) SetCCXContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CCX, uint(v))
+}
+func (m RegisterManager) GetCDX() uint             { return must(m.TryGetCDX()) }
+func (m RegisterManager) TryGetCDX() (uint, error) { return m.GetCDXContext(context.Background()) }
+func (m RegisterManager) GetCDXContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CDX)
+	return uint(v), err
+}
+func (m RegisterManager) SetCDX(v uint) bool { return must(m.TrySetCDX(v)) }
+func (m RegisterManager) TrySetCDX(v uint) (bool, error) {
+	return m.SetCDXContext(context.Background(), v)
+}
+func (m RegisterManager) SetCDXContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CDX, uint(v))
+}
+func (m RegisterManager) GetCDI() uint             { return must(m.TryGetCDI()) }
+func (m RegisterManager) TryGetCDI() (uint, error) { return m.GetCDIContext(context.Background()) }
+func (m RegisterManager) GetCDIContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CDI)
+	return uint(v), err
+}
+func (m RegisterManager) SetCDI(v uint) bool { return must(m.TrySetCDI(v)) }
+func (m RegisterManager) TrySetCDI(v uint) (bool, error) {
+	return m.SetCDIContext(context.Background(), v)
+}
+func (m RegisterManager) SetCDIContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CDI, uint(v))
+}
+func (m RegisterManager) GetCSI() uint             { return must(m.TryGetCSI()) }
+func (m RegisterManager) TryGetCSI() (uint, error) { return m.GetCSIContext(context.Background()) }
+func (m RegisterManager) GetCSIContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CSI)
+	return uint(v), err
+}
+func (m RegisterManager) SetCSI(v uint) bool { return must(m.TrySetCSI(v)) }
+func (m RegisterManager) TrySetCSI(v uint) (bool, error) {
+	return m.SetCSIContext(context.Background(), v)
+}
+func (m RegisterManager) SetCSIContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CSI, uint(v))
+}
+func (m RegisterManager) GetCBP() uint             { return must(m.TryGetCBP()) }
+func (m RegisterManager) TryGetCBP() (uint, error) { return m.GetCBPContext(context.Background()) }
+func (m RegisterManager) GetCBPContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CBP)
+	return uint(v), err
 }
-func (m RegisterManager) GetR10W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R10W"})
-}
-func (m RegisterManager) SetR10W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R10W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR10B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R10B"})
-}
-func (m RegisterManager) SetR10B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R10B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR11() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R11"})
-}
-func (m RegisterManager) SetR11(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R11", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR11D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R11D"})
-}
-func (m RegisterManager) SetR11D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R11D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR11W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R11W"})
-}
-func (m RegisterManager) SetR11W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R11W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR11B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R11B"})
-}
-func (m RegisterManager) SetR11B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R11B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR12() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R12"})
-}
-func (m RegisterManager) SetR12(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R12", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR12D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R12D"})
-}
-func (m RegisterManager) SetR12D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R12D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR12W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R12W"})
-}
-func (m RegisterManager) SetR12W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R12W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR12B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R12B"})
-}
-func (m RegisterManager) SetR12B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R12B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR13() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R13"})
-}
-func (m RegisterManager) SetR13(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R13", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR13D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R13D"})
-}
-func (m RegisterManager) SetR13D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R13D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR13W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R13W"})
-}
-func (m RegisterManager) SetR13W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R13W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR13B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R13B"})
-}
-func (m RegisterManager) SetR13B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R13B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR14() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R14"})
-}
-func (m RegisterManager) SetR14(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R14", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR14D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R14D"})
-}
-func (m RegisterManager) SetR14D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R14D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR14W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R14W"})
-}
-func (m RegisterManager) SetR14W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R14W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR14B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R14B"})
-}
-func (m RegisterManager) SetR14B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R14B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR15() uint64 {
-	return request[uint64]("Register/Get", map[string]string{"register": "R15"})
-}
-func (m RegisterManager) SetR15(v uint64) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R15", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR15D() uint32 {
-	return request[uint32]("Register/Get", map[string]string{"register": "R15D"})
-}
-func (m RegisterManager) SetR15D(v uint32) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R15D", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR15W() uint16 {
-	return request[uint16]("Register/Get", map[string]string{"register": "R15W"})
-}
-func (m RegisterManager) SetR15W(v uint16) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R15W", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetR15B() uint8 {
-	return request[uint8]("Register/Get", map[string]string{"register": "R15B"})
-}
-func (m RegisterManager) SetR15B(v uint8) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "R15B", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCIP() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CIP"})
-}
-func (m RegisterManager) SetCIP(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CIP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCSP() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CSP"})
-}
-func (m RegisterManager) SetCSP(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CSP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCAX() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CAX"})
-}
-func (m RegisterManager) SetCAX(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CAX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCBX() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CBX"})
-}
-func (m RegisterManager) SetCBX(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CBX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCCX() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CCX"})
-}
-func (m RegisterManager) SetCCX(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CCX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCDX() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CDX"})
-}
-func (m RegisterManager) SetCDX(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CDX", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCDI() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CDI"})
-}
-func (m RegisterManager) SetCDI(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CDI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCSI() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CSI"})
-}
-func (m RegisterManager) SetCSI(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CSI", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCBP() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CBP"})
-}
-func (m RegisterManager) SetCBP(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CBP", "value": strconv.FormatUint(uint64(v), 16)})
-}
-func (m RegisterManager) GetCFLAGS() uint {
-	return request[uint]("Register/Get", map[string]string{"register": "CFLAGS"})
-}
-func (m RegisterManager) SetCFLAGS(v uint) bool {
-	return request[bool]("Register/Set", map[string]string{"register": "CFLAGS", "value": strconv.FormatUint(uint64(v), 16)})
+func (m RegisterManager) SetCBP(v uint) bool { return must(m.TrySetCBP(v)) }
+func (m RegisterManager) TrySetCBP(v uint) (bool, error) {
+	return m.SetCBPContext(context.Background(), v)
+}
+func (m RegisterManager) SetCBPContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CBP, uint(v))
+}
+func (m RegisterManager) GetCFLAGS() uint { return must(m.TryGetCFLAGS()) }
+func (m RegisterManager) TryGetCFLAGS() (uint, error) {
+	return m.GetCFLAGSContext(context.Background())
+}
+func (m RegisterManager) GetCFLAGSContext(ctx context.Context) (uint, error) {
+	v, err := m.GetContext(ctx, CFLAGS)
+	return uint(v), err
+}
+func (m RegisterManager) SetCFLAGS(v uint) bool { return must(m.TrySetCFLAGS(v)) }
+func (m RegisterManager) TrySetCFLAGS(v uint) (bool, error) {
+	return m.SetCFLAGSContext(context.Background(), v)
+}
+func (m RegisterManager) SetCFLAGSContext(ctx context.Context, v uint) (bool, error) {
+	return m.SetContext(ctx, CFLAGS, uint(v))
 }
 
 func (m RegisterManager) Get(reg RegisterEnum) uint {
+	mylog.Check(m.checkKnown(reg))
 	switch reg {
 	case DR0:
 		return uint(m.GetDR0())
@@ -714,6 +1312,7 @@ func (m RegisterManager) Get(reg RegisterEnum) uint {
 }
 
 func (m RegisterManager) Set(reg RegisterEnum, value uint) bool {
+	mylog.Check(m.checkKnown(reg))
 	switch reg {
 	case DR0:
 		return m.SetDR0(uint(value))
@@ -892,183 +1491,6 @@ func (m RegisterManager) Set(reg RegisterEnum, value uint) bool {
 	}
 }
 
-const DefaultX64dbgServer = "http://127.0.0.1:8888/"
-
-var client = &http.Client{
-	Timeout: 15 * time.Second,
-	Transport: &http.Transport{
-		DisableKeepAlives: true,
-	},
-}
-
-//	Type type Ordered interface {
-//		~int | ~int8 | ~int16 | ~int32 | ~int64 |
-//			~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
-//			~float32 | ~float64 |
-//			~string
-//	}
-type Type interface {
-	cmp.Ordered |
-		bool |
-		[]byte |
-		moduleInfo |
-		[]moduleInfo |
-		moduleSectionInfo |
-		[]moduleSectionInfo |
-		moduleExport |
-		[]moduleExport |
-		moduleImport |
-		[]moduleImport |
-		memoryBase |
-		disassemblerAddress |
-		disassembleRip |
-		disassembleRipWithSetupIn |
-		assemblerResult |
-		void
-}
-
-func request[T Type](endpoint string, params map[string]string) T {
-	x64dbgServerURL := DefaultX64dbgServer
-	url := x64dbgServerURL + endpoint
-
-	// 添加查询参数
-	if len(params) > 0 {
-		query := ""
-		for key, value := range params {
-			query += key + "=" + value + "&"
-		}
-		url += "?" + strings.TrimSuffix(query, "&")
-	}
-
-	resp := mylog.Check2(client.Get(url))
-	defer func() {
-		mylog.Check(resp.Body.Close())
-	}()
-
-	body := mylog.Check2(io.ReadAll(resp.Body))
-
-	if resp.StatusCode != http.StatusOK {
-		mylog.Check(fmt.Sprintf("Error %d: %s", resp.StatusCode, string(body)))
-	}
-
-	str := strings.TrimSpace(string(body))
-	base := 10
-	if strings.HasPrefix(str, "0x") {
-		base = 16
-	}
-	str = strings.TrimPrefix(str, "0x")
-	var zero T
-	switch v := any(zero).(type) {
-	case bool:
-		if strings.EqualFold(str, "true") {
-			return any(true).(T)
-		}
-		if strings.EqualFold(str, "false") {
-			return any(false).(T)
-		}
-	case []byte:
-		b := mylog.Check2(hex.DecodeString(str))
-		return any(b).(T)
-	case int:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 64))
-		return any(value).(T)
-	case int8:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 8))
-		return any(value).(T)
-	case int16:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 16))
-		return any(value).(T)
-	case int32:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 32))
-		return any(value).(T)
-	case int64:
-
-		value := mylog.Check2(strconv.ParseInt(str, base, 64))
-		return any(value).(T)
-	case uint:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case uint8:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 8))
-		return any(value).(T)
-	case uint16:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 16))
-		return any(value).(T)
-	case uint32:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 32))
-		return any(value).(T)
-	case uint64:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case uintptr:
-
-		value := mylog.Check2(strconv.ParseUint(str, base, 64))
-		return any(value).(T)
-	case float32:
-		value := mylog.Check2(strconv.ParseFloat(str, 32))
-		return any(value).(T)
-	case float64:
-		value := mylog.Check2(strconv.ParseFloat(str, 64))
-		return any(value).(T)
-	case string:
-		return any(str).(T)
-
-		//todo 处理cpp服务端的字段返回 0x12345678 这种格式，我估计json会解码失败
-	case moduleInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleSectionInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleSectionInfo:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleExport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleExport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case moduleImport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case []moduleImport:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case memoryBase:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassemblerAddress:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassembleRip:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case disassembleRipWithSetupIn:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case assemblerResult:
-		mylog.Check(json.Unmarshal(body, &v))
-		return any(v).(T)
-	case void:
-		return any(nil).(T)
-
-	}
-	panic("not support type")
-}
-
 const (
 	DR0 RegisterEnum = iota
 	DR1
@@ -1160,3 +1582,331 @@ const (
 	CBP
 	CFLAGS
 )
+
+var registerEnumNames = [...]string{
+	"DR0",
+	"DR1",
+	"DR2",
+	"DR3",
+	"DR6",
+	"DR7",
+	"EAX",
+	"AX",
+	"AH",
+	"AL",
+	"EBX",
+	"BX",
+	"BH",
+	"BL",
+	"ECX",
+	"CX",
+	"CH",
+	"CL",
+	"EDX",
+	"DX",
+	"DH",
+	"DL",
+	"EDI",
+	"DI",
+	"ESI",
+	"SI",
+	"EBP",
+	"BP",
+	"ESP",
+	"SP",
+	"EIP",
+	"RAX",
+	"RBX",
+	"RCX",
+	"RDX",
+	"RSI",
+	"SIL",
+	"RDI",
+	"DIL",
+	"RBP",
+	"BPL",
+	"RSP",
+	"SPL",
+	"RIP",
+	"R8",
+	"R8D",
+	"R8W",
+	"R8B",
+	"R9",
+	"R9D",
+	"R9W",
+	"R9B",
+	"R10",
+	"R10D",
+	"R10W",
+	"R10B",
+	"R11",
+	"R11D",
+	"R11W",
+	"R11B",
+	"R12",
+	"R12D",
+	"R12W",
+	"R12B",
+	"R13",
+	"R13D",
+	"R13W",
+	"R13B",
+	"R14",
+	"R14D",
+	"R14W",
+	"R14B",
+	"R15",
+	"R15D",
+	"R15W",
+	"R15B",
+	"CIP",
+	"CSP",
+	"CAX",
+	"CBX",
+	"CCX",
+	"CDX",
+	"CDI",
+	"CSI",
+	"CBP",
+	"CFLAGS",
+}
+
+// registerSizes is the width of each register in bytes, 0 for the pointer sized ones: DR0-DR7 and the C aliases
+var registerSizes = [...]int{
+	DR0:    0,
+	DR1:    0,
+	DR2:    0,
+	DR3:    0,
+	DR6:    0,
+	DR7:    0,
+	EAX:    4,
+	AX:     2,
+	AH:     1,
+	AL:     1,
+	EBX:    4,
+	BX:     2,
+	BH:     1,
+	BL:     1,
+	ECX:    4,
+	CX:     2,
+	CH:     1,
+	CL:     1,
+	EDX:    4,
+	DX:     2,
+	DH:     1,
+	DL:     1,
+	EDI:    4,
+	DI:     2,
+	ESI:    4,
+	SI:     2,
+	EBP:    4,
+	BP:     2,
+	ESP:    4,
+	SP:     2,
+	EIP:    4,
+	RAX:    8,
+	RBX:    8,
+	RCX:    8,
+	RDX:    8,
+	RSI:    8,
+	SIL:    1,
+	RDI:    8,
+	DIL:    1,
+	RBP:    8,
+	BPL:    1,
+	RSP:    8,
+	SPL:    1,
+	RIP:    8,
+	R8:     8,
+	R8D:    4,
+	R8W:    2,
+	R8B:    1,
+	R9:     8,
+	R9D:    4,
+	R9W:    2,
+	R9B:    1,
+	R10:    8,
+	R10D:   4,
+	R10W:   2,
+	R10B:   1,
+	R11:    8,
+	R11D:   4,
+	R11W:   2,
+	R11B:   1,
+	R12:    8,
+	R12D:   4,
+	R12W:   2,
+	R12B:   1,
+	R13:    8,
+	R13D:   4,
+	R13W:   2,
+	R13B:   1,
+	R14:    8,
+	R14D:   4,
+	R14W:   2,
+	R14B:   1,
+	R15:    8,
+	R15D:   4,
+	R15W:   2,
+	R15B:   1,
+	CIP:    0,
+	CSP:    0,
+	CAX:    0,
+	CBX:    0,
+	CCX:    0,
+	CDX:    0,
+	CDI:    0,
+	CSI:    0,
+	CBP:    0,
+	CFLAGS: 0,
+}
+
+var registersX86 = []RegisterEnum{
+	DR0,
+	DR1,
+	DR2,
+	DR3,
+	DR6,
+	DR7,
+	EAX,
+	AX,
+	AH,
+	AL,
+	EBX,
+	BX,
+	BH,
+	BL,
+	ECX,
+	CX,
+	CH,
+	CL,
+	EDX,
+	DX,
+	DH,
+	DL,
+	EDI,
+	DI,
+	ESI,
+	SI,
+	EBP,
+	BP,
+	ESP,
+	SP,
+	EIP,
+	CIP,
+	CSP,
+	CAX,
+	CBX,
+	CCX,
+	CDX,
+	CDI,
+	CSI,
+	CBP,
+	CFLAGS,
+}
+
+var registersX64 = []RegisterEnum{
+	DR0,
+	DR1,
+	DR2,
+	DR3,
+	DR6,
+	DR7,
+	EAX,
+	AX,
+	AH,
+	AL,
+	EBX,
+	BX,
+	BH,
+	BL,
+	ECX,
+	CX,
+	CH,
+	CL,
+	EDX,
+	DX,
+	DH,
+	DL,
+	EDI,
+	DI,
+	ESI,
+	SI,
+	EBP,
+	BP,
+	ESP,
+	SP,
+	EIP,
+	RAX,
+	RBX,
+	RCX,
+	RDX,
+	RSI,
+	SIL,
+	RDI,
+	DIL,
+	RBP,
+	BPL,
+	RSP,
+	SPL,
+	RIP,
+	R8,
+	R8D,
+	R8W,
+	R8B,
+	R9,
+	R9D,
+	R9W,
+	R9B,
+	R10,
+	R10D,
+	R10W,
+	R10B,
+	R11,
+	R11D,
+	R11W,
+	R11B,
+	R12,
+	R12D,
+	R12W,
+	R12B,
+	R13,
+	R13D,
+	R13W,
+	R13B,
+	R14,
+	R14D,
+	R14W,
+	R14B,
+	R15,
+	R15D,
+	R15W,
+	R15B,
+	CIP,
+	CSP,
+	CAX,
+	CBX,
+	CCX,
+	CDX,
+	CDI,
+	CSI,
+	CBP,
+	CFLAGS,
+}
+
+func (r RegisterEnum) String() string {
+	if r < 0 || int(r) >= len(registerEnumNames) {
+		return "RegisterEnum(" + strconv.Itoa(int(r)) + ")"
+	}
+	return registerEnumNames[r]
+}
+
+func RegisterEnumByName(name string) (RegisterEnum, bool) {
+	for i, s := range registerEnumNames {
+		if strings.EqualFold(s, name) {
+			return RegisterEnum(i), true
+		}
+	}
+	return 0, false
+}
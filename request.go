@@ -0,0 +1,183 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+//	Type type Ordered interface {
+//		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+//			~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+//			~float32 | ~float64 |
+//			~string
+//	}
+type Type interface {
+	cmp.Ordered |
+		bool |
+		[]byte |
+		moduleInfo |
+		[]moduleInfo |
+		moduleSectionInfo |
+		[]moduleSectionInfo |
+		moduleExport |
+		[]moduleExport |
+		moduleImport |
+		[]moduleImport |
+		memoryBase |
+		disassemblerAddress |
+		disassembleRip |
+		disassembleRipWithSetupIn |
+		assemblerResult |
+		Breakpoint |
+		[]Breakpoint |
+		DebugEvent |
+		[]ThreadInfo |
+		[]MemoryRegion |
+		PageProtection |
+		binaryBytes |
+		[]HexInt |
+		[]Xref |
+		SymbolInfo |
+		[]SymbolInfo |
+		[]StackFrame |
+		[]StackSlot |
+		registerContext |
+		void
+}
+
+// request panics on any failure, see tryRequest for the error returning form.
+func request[T Type](c *Client, endpoint string, params map[string]string) T {
+	return must(tryRequest[T](c, context.Background(), endpoint, params))
+}
+
+// must is mylog.Check2 without its nil slice check, an empty module list is not an error.
+func must[T any](v T, err error) T {
+	mylog.Check(err)
+	return v
+}
+
+func tryRequest[T Type](c *Client, ctx context.Context, endpoint string, params map[string]string) (T, error) {
+	return do[T](c, ctx, http.MethodGet, endpoint, params, "")
+}
+
+// tryPost sends payload as the request body, the plugin reads it in place of the query parameter for
+// ExecCommand and Memory/Write so long commands and hex data are not limited by the URL length.
+func tryPost[T Type](c *Client, ctx context.Context, endpoint string, params map[string]string, payload string) (T, error) {
+	return do[T](c, ctx, http.MethodPost, endpoint, params, payload)
+}
+
+func do[T Type](c *Client, ctx context.Context, method, endpoint string, params map[string]string, payload string) (T, error) {
+	var zero T
+	c = c.orDefault()
+	u := c.BaseURL + endpoint
+
+	// 添加查询参数，表达式和特征码里的空格、&、[] 都要转义
+	if len(params) > 0 {
+		query := url.Values{}
+		for key, value := range params {
+			query.Set(key, value)
+		}
+		u += "?" + query.Encode()
+	}
+
+	resp, err := c.do(ctx, method, u, payload)
+	if err != nil {
+		return zero, fmt.Errorf("x64dbg: %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, fmt.Errorf("x64dbg: %s: %w", endpoint, err)
+	}
+
+	if v := resp.Header.Get(wireSchemaHeader); v != "" && v != strconv.Itoa(WireSchemaVersion) {
+		return zero, fmt.Errorf("x64dbg: %s: plugin schema %s, client schema %d: %w", endpoint, v, WireSchemaVersion, ErrSchemaVersion)
+	}
+
+	c.noteBits(resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		return zero, &HTTPStatusError{Endpoint: endpoint, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	if err := decode(&zero, body); err != nil {
+		return zero, &DecodeError{Endpoint: endpoint, Type: reflect.TypeFor[T]().String(), Body: string(body), Err: err}
+	}
+	return zero, nil
+}
+
+var errUnsupportedType = errors.New("not support type")
+
+// binaryBytes is a Memory/Read?encoding=binary body, the raw bytes as they are.
+type binaryBytes []byte
+
+// decode 按底层类型解码，HexInt、HexBytes 这类命名类型也能落到对应分支
+func decode[T any](out *T, body []byte) error {
+	if raw, ok := any(out).(*binaryBytes); ok {
+		*raw = append(binaryBytes(nil), body...)
+		return nil
+	}
+	str := strings.TrimSpace(string(body))
+	base := 10
+	if strings.HasPrefix(str, "0x") {
+		base = 16
+	}
+	str = strings.TrimPrefix(str, "0x")
+
+	v := reflect.ValueOf(out).Elem()
+	switch v.Kind() {
+	case reflect.Interface: // void
+		return nil
+	case reflect.Bool:
+		// 插件对 Set/Write 类接口返回 "xxx successfully" 之类的文本，状态码 200 即视为成功
+		v.SetBool(!strings.EqualFold(str, "false"))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, base, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(str, base, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		v.SetString(str)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := hex.DecodeString(str)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		return json.Unmarshal(body, out)
+	case reflect.Struct:
+		return json.Unmarshal(body, out)
+	default:
+		return errUnsupportedType
+	}
+	return nil
+}
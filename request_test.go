@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// recordPlugin remembers the last request so tests can check what went over the wire.
+type recordPlugin struct {
+	method string
+	query  url.Values
+	body   string
+}
+
+func (p *recordPlugin) client(t *testing.T, reply string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		p.method, p.query, p.body = r.Method, r.URL.Query(), string(b)
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL)
+}
+
+func TestRequestQueryEncoding(t *testing.T) {
+	var p recordPlugin
+	x := p.client(t, "0x10").X64dbg()
+
+	for _, expr := range []string{"[rsp+8]", "a&b", "rax == 0x10 && rcx != 0", "kernel32.dll:CreateFileW"} {
+		x.Misc.ParseExpression(expr)
+		if got := p.query.Get("expression"); got != expr {
+			t.Errorf("ParseExpression(%q) sent %q", expr, got)
+		}
+	}
+
+	x.Pattern.FindMemory(0x401000, 0x1000, "48 8B ?? 24 #")
+	if got := p.query.Get("pattern"); got != "48 8B ?? 24 #" {
+		t.Errorf("FindMemory sent pattern %q", got)
+	}
+}
+
+func TestRequestPostBody(t *testing.T) {
+	var p recordPlugin
+	x := p.client(t, "Memory written successfully").X64dbg()
+
+	data := bytes.Repeat([]byte{0x90, 0xcc}, 32*1024)
+	if !x.Memory.Write(0x401000, data) {
+		t.Fatal("Memory.Write failed")
+	}
+	if p.method != http.MethodPost || p.query.Get("addr") != "0x401000" || p.query.Has("data") {
+		t.Errorf("Memory.Write: %s %v", p.method, p.query)
+	}
+	if len(p.body) != 2*len(data) || p.body[:4] != "90cc" {
+		t.Errorf("Memory.Write body: %d bytes", len(p.body))
+	}
+
+	cmd := `findasm "mov dword ptr ds:[edi+0x5E8],eax", 0x401000`
+	x.Command.Exec(cmd)
+	if p.method != http.MethodPost || p.body != cmd || len(p.query) != 0 {
+		t.Errorf("Exec: %s %v %q", p.method, p.query, p.body)
+	}
+}
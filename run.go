@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// StopKind is why the debugger paused, see StopReason.
+type StopKind int
+
+const (
+	StopPaused     StopKind = iota // Pause, the GUI or anything without its own event
+	StopBreakpoint                 // any breakpoint kind, including the one RunToAddress sets
+	StopException
+	StopStep
+	StopExited // the debuggee exited, the debugger does not pause again
+)
+
+var stopKindNames = [...]string{"paused", "breakpoint", "exception", "step", "exited"}
+
+func (k StopKind) String() string {
+	if k >= 0 && int(k) < len(stopKindNames) {
+		return stopKindNames[k]
+	}
+	return "StopKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// StopReason is what RunUntilPause, StepOverN and RunToAddress waited for.
+type StopReason struct {
+	Kind     StopKind
+	Address  HexInt // the breakpoint or exception address, else CIP where the debugger paused
+	ThreadID int
+	// StopBreakpoint: the breakpoint that was hit, Breakpoints.Get/Delete take it as is
+	Breakpoint *Breakpoint
+	// StopException: the exception code, StopExited: the exit code of the process
+	Code HexInt
+}
+
+// waitStop runs cmd and follows the Events stream until the debugger pauses again or the debuggee
+// exits. The subscription is made before cmd runs so a quick stop is not missed. fallback is the
+// Kind of a pause no breakpoint or exception explains.
+func (d debug) waitStop(ctx context.Context, cmd string, fallback StopKind) (StopReason, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := d.TryEvents(ctx)
+	if err != nil {
+		return StopReason{}, err
+	}
+	if _, err := (command{d.client}).ExecContext(ctx, cmd); err != nil {
+		return StopReason{}, err
+	}
+
+	// 断点和异常只有紧跟着 paused 才是这次暂停的原因，x64dbg 放过去不停的异常（比如 C++ 的
+	// 0xE06D7363 第一次机会）后面跟的是别的事件
+	reason := StopReason{Kind: fallback}
+	for e := range events {
+		switch e.Kind {
+		case EventBreakpoint:
+			reason = StopReason{Kind: StopBreakpoint, Address: e.Address, ThreadID: e.ThreadID, Breakpoint: e.Breakpoint}
+			continue
+		case EventException:
+			reason = StopReason{Kind: StopException, Address: e.Address, ThreadID: e.ThreadID, Code: e.Code}
+			continue
+		case EventExitProcess:
+			return StopReason{Kind: StopExited, Code: e.Code}, nil
+		case EventPaused:
+			if reason.Address == 0 {
+				reason.Address = e.Address
+			}
+			if reason.ThreadID == 0 {
+				reason.ThreadID = e.ThreadID
+			}
+			return reason, nil
+		}
+		reason = StopReason{Kind: fallback}
+	}
+	if err := ctx.Err(); err != nil {
+		return StopReason{}, err
+	}
+	return StopReason{}, fmt.Errorf("x64dbg: %s: event stream closed before the debugger paused", cmd)
+}
+
+// RunUntilPause resumes the debuggee and blocks until it pauses again, unlike Run which returns at once.
+func (d debug) RunUntilPause() StopReason {
+	return must(d.TryRunUntilPause())
+}
+func (d debug) TryRunUntilPause() (StopReason, error) {
+	return d.RunUntilPauseContext(context.Background())
+}
+func (d debug) RunUntilPauseContext(ctx context.Context) (StopReason, error) {
+	return d.waitStop(ctx, "run", StopPaused)
+}
+
+// StepOverN steps over n instructions, it stops early and returns the reason when anything but the
+// step itself pauses the debugger.
+func (d debug) StepOverN(n int) StopReason {
+	return must(d.TryStepOverN(n))
+}
+func (d debug) TryStepOverN(n int) (StopReason, error) {
+	return d.StepOverNContext(context.Background(), n)
+}
+func (d debug) StepOverNContext(ctx context.Context, n int) (StopReason, error) {
+	reason := StopReason{Kind: StopStep}
+	for i := 0; i < n; i++ {
+		var err error
+		reason, err = d.waitStop(ctx, "StepOver", StopStep)
+		if err != nil || reason.Kind != StopStep {
+			return reason, err
+		}
+	}
+	return reason, nil
+}
+
+// RunToAddress runs until address with a singleshot breakpoint, which is removed again when the
+// debuggee stops somewhere else first. An existing breakpoint at address is used as is.
+func (d debug) RunToAddress(address int) StopReason {
+	return must(d.TryRunToAddress(address))
+}
+func (d debug) TryRunToAddress(address int) (StopReason, error) {
+	return d.RunToAddressContext(context.Background(), address)
+}
+func (d debug) RunToAddressContext(ctx context.Context, address int) (StopReason, error) {
+	b := breakpoints{d.client}
+	temporary := Breakpoint{Kind: BreakpointNormal, Address: HexInt(address)}
+	_, err := b.GetContext(ctx, temporary)
+	owned := errors.Is(err, ErrNotFound)
+	if err != nil && !owned {
+		return StopReason{}, err
+	}
+	if owned {
+		if err := b.exec(ctx, fmt.Sprintf(`SetBPX 0x%x, "RunToAddress", ss`, address)); err != nil {
+			return StopReason{}, err
+		}
+	}
+	reason, err := d.waitStop(ctx, "run", StopPaused)
+	if owned && (err != nil || reason.Kind != StopBreakpoint || reason.Address != temporary.Address) {
+		// 没走到目标地址，单次断点还在，别留给用户
+		b.DeleteContext(context.WithoutCancel(ctx), temporary)
+	}
+	return reason, err
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDebuggee answers ExecCommand with the events script returns for the command, sent to the
+// /Events subscribers the way cbDebugEvent would.
+type fakeDebuggee struct {
+	fakeServer
+	mu          sync.Mutex
+	subscribers []chan string
+	bps         fakeBreakpointPlugin
+	script      func(cmd string) []string
+}
+
+func (p *fakeDebuggee) client(t *testing.T) x64dbg {
+	t.Helper()
+	p.handle("/Events", func(w http.ResponseWriter, r *http.Request) {
+		events := make(chan string, 16)
+		p.mu.Lock()
+		p.subscribers = append(p.subscribers, events)
+		p.mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case e := <-events:
+				fmt.Fprintf(w, "data: %s\n\n", e)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	p.handle("/ExecCommand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		cmd := string(body)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if strings.HasPrefix(cmd, "SetBPX") || strings.HasPrefix(cmd, "DeleteBPX") {
+			p.bps.exec(cmd)
+		} else {
+			for _, e := range p.script(cmd) {
+				for _, s := range p.subscribers {
+					s <- e
+				}
+			}
+		}
+		w.Write([]byte("Command executed successfully (no output captured)"))
+	})
+	p.handle("/Breakpoint/List", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		json.NewEncoder(w).Encode(append([]Breakpoint{}, p.bps.bps...))
+	})
+	return p.fakeServer.client(t).X64dbg()
+}
+
+func TestRunUntilPause(t *testing.T) {
+	bp := `{"type":"breakpoint","addr":"0x401000","threadId":7,"breakpoint":{"type":1,"addr":"0x401000","enabled":true,"hitCount":1}}`
+	cases := []struct {
+		name   string
+		events []string
+		want   StopReason
+	}{
+		{"breakpoint", []string{`{"type":"resumed"}`, bp, `{"type":"paused","addr":"0x401000","threadId":7}`},
+			StopReason{Kind: StopBreakpoint, Address: 0x401000, ThreadID: 7, Breakpoint: &Breakpoint{Kind: BreakpointNormal, Address: 0x401000, Enabled: true, HitCount: 1}}},
+		{"exception", []string{`{"type":"resumed"}`, `{"type":"loadDll","addr":"0x7ff000000000","module":"user32.dll"}`,
+			`{"type":"exception","addr":"0x401005","threadId":7,"code":"0xc0000005","firstChance":true}`, `{"type":"paused","addr":"0x401005","threadId":7}`},
+			StopReason{Kind: StopException, Address: 0x401005, ThreadID: 7, Code: 0xc0000005}},
+		{"pause", []string{`{"type":"resumed"}`, `{"type":"paused","addr":"0x401010","threadId":8}`},
+			StopReason{Kind: StopPaused, Address: 0x401010, ThreadID: 8}},
+		// x64dbg 放过去的 C++ 异常不是之后那次暂停的原因
+		{"passed exception", []string{`{"type":"resumed"}`,
+			`{"type":"exception","addr":"0x7ffd3e0b5a22","threadId":7,"code":"0xe06d7363","firstChance":true}`,
+			`{"type":"createThread","addr":"0x401500","threadId":9}`, `{"type":"paused","addr":"0x401010","threadId":8}`},
+			StopReason{Kind: StopPaused, Address: 0x401010, ThreadID: 8}},
+		{"exit", []string{`{"type":"resumed"}`, `{"type":"exitThread","threadId":7,"code":"0x0"}`, `{"type":"exitProcess","code":"0x2a"}`},
+			StopReason{Kind: StopExited, Code: 0x2a}},
+	}
+	for _, c := range cases {
+		p := &fakeDebuggee{script: func(string) []string { return c.events }}
+		if got := p.client(t).Debug.RunUntilPause(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s:\n got %+v\nwant %+v", c.name, got, c.want)
+		}
+		if commands := p.bodies("/ExecCommand"); len(commands) != 1 || commands[0] != "run" {
+			t.Errorf("%s: commands %q", c.name, commands)
+		}
+	}
+
+	p := &fakeDebuggee{script: func(string) []string { return []string{`{"type":"resumed"}`} }}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := p.client(t).Debug.RunUntilPauseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("never paused: %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestStepOverN(t *testing.T) {
+	cip := 0x401000
+	p := &fakeDebuggee{script: func(string) []string {
+		cip += 2
+		if cip == 0x401008 {
+			return []string{`{"type":"resumed"}`, `{"type":"breakpoint","addr":"0x401008","threadId":1,"breakpoint":{"type":1,"addr":"0x401008"}}`, `{"type":"paused","addr":"0x401008","threadId":1}`}
+		}
+		return []string{`{"type":"resumed"}`, `{"type":"stepped","addr":"` + fmt.Sprintf("0x%x", cip) + `","threadId":1}`, `{"type":"paused","addr":"` + fmt.Sprintf("0x%x", cip) + `","threadId":1}`}
+	}}
+	d := p.client(t).Debug
+
+	if got := d.StepOverN(2); got.Kind != StopStep || got.Address != 0x401004 {
+		t.Errorf("StepOverN(2) = %+v", got)
+	}
+	if got := d.StepOverN(5); got.Kind != StopBreakpoint || got.Address != 0x401008 {
+		t.Errorf("StepOverN(5) stopped at %+v, want the breakpoint at 0x401008", got)
+	}
+	if commands := p.bodies("/ExecCommand"); len(commands) != 4 {
+		t.Errorf("%d StepOver commands, want 4", len(commands))
+	}
+}
+
+func TestRunToAddress(t *testing.T) {
+	var p *fakeDebuggee
+	p = &fakeDebuggee{script: func(string) []string {
+		// 停在第一个断点上，单次断点命中后 x64dbg 会自己删掉
+		first := p.bps.bps[0]
+		if first.Address == 0x402000 {
+			p.bps.bps = p.bps.bps[1:]
+		}
+		return []string{
+			fmt.Sprintf(`{"type":"breakpoint","addr":"0x%x","threadId":1,"breakpoint":{"type":1,"addr":"0x%x"}}`, first.Address, first.Address),
+			fmt.Sprintf(`{"type":"paused","addr":"0x%x","threadId":1}`, first.Address),
+		}
+	}}
+	d := p.client(t).Debug
+
+	if got := d.RunToAddress(0x402000); got.Kind != StopBreakpoint || got.Address != 0x402000 {
+		t.Errorf("RunToAddress = %+v", got)
+	}
+	if want := []string{`SetBPX 0x402000, "RunToAddress", ss`, "run"}; !reflect.DeepEqual(p.bodies("/ExecCommand"), want) {
+		t.Errorf("commands %q, want %q", p.bodies("/ExecCommand"), want)
+	}
+
+	p.reset()
+	p.bps.bps = []Breakpoint{{Kind: BreakpointNormal, Address: 0x401500}}
+	if got := d.RunToAddress(0x402000); got.Address != 0x401500 {
+		t.Errorf("RunToAddress stopped at %+v, want the breakpoint at 0x401500", got)
+	}
+	if want := []string{`SetBPX 0x402000, "RunToAddress", ss`, "run", "DeleteBPX 0x402000"}; !reflect.DeepEqual(p.bodies("/ExecCommand"), want) {
+		t.Errorf("commands %q, want %q", p.bodies("/ExecCommand"), want)
+	}
+	if len(p.bps.bps) != 1 {
+		t.Errorf("temporary breakpoint left behind: %+v", p.bps.bps)
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+// Wire schema of the JSON bodies MCPx64dbg.cpp sends back.
+//
+// The plugin tags every response with the X-MCPx64dbg-Schema header, a client refuses a body whose
+// version differs from WireSchemaVersion (ErrSchemaVersion) instead of decoding zeroes. Plugins built
+// before the header existed send nothing and are treated as version 1.
+//
+// Conventions, bump WireSchemaVersion when any of them change:
+//   - addresses, sizes, rva and ordinals are hex strings "0x401000", see HexInt
+//   - byte buffers are plain hex strings without prefix "4d5a90", see HexBytes
+//   - counts and instruction sizes are JSON numbers
+//   - names and paths are JSON escaped strings
+//
+// testdata/plugin holds recorded plugin responses, schema_test.go decodes each of them and events_test.go
+// replays the recorded /Events stream.
+const WireSchemaVersion = 1
+
+const wireSchemaHeader = "X-MCPx64dbg-Schema"
+
+// wireBitsHeader tells which debugger the plugin runs in, 32 for x32dbg and 64 for x64dbg, see Client.Bits.
+const wireBitsHeader = "X-MCPx64dbg-Bits"
+
+// assemblerResult is Assembler/Assemble:
+//
+//	{"success":true,"size":3,"bytes":"4889c8"}
+type assemblerResult struct {
+	Success bool     `json:"success"`
+	Size    int      `json:"size"`
+	Data    HexBytes `json:"bytes"`
+}
+
+// disassemblerAddress is Disasm/GetInstruction, Disasm/GetInstructionRange returns an array of it:
+//
+//	{"address":"0x401000","instruction":"push rbp","size":1}
+type disassemblerAddress struct {
+	Address     HexInt `json:"address"`
+	Instruction string `json:"instruction"`
+	Size        int    `json:"size"`
+}
+
+// disassembleRip is Disasm/GetInstructionAtRIP:
+//
+//	{"rip":"0x401000","instruction":"push rbp","size":1}
+type disassembleRip struct {
+	Rip         HexInt `json:"rip"`
+	Instruction string `json:"instruction"`
+	Size        int    `json:"size"`
+}
+
+// disassembleRipWithSetupIn is Disasm/StepInWithDisasm:
+//
+//	{"step_result":"Step in executed","rip":"0x401001","instruction":"mov rbp, rsp","size":3}
+type disassembleRipWithSetupIn struct {
+	StepResult  string `json:"step_result"`
+	Rip         HexInt `json:"rip"`
+	Instruction string `json:"instruction"`
+	Size        int    `json:"size"`
+}
+
+// memoryBase is MemoryBase:
+//
+//	{"base_address":"0x400000","size":"0x1000"}
+type memoryBase struct {
+	BaseAddress HexInt `json:"base_address"`
+	Size        HexInt `json:"size"`
+}
+
+// moduleInfo is Module/Info and one entry of Module/GetList (GetModuleList):
+//
+//	{"name":"a.exe","base":"0x400000","size":"0x5000","entry":"0x401000","sectionCount":3,"path":"C:\\a.exe"}
+type moduleInfo struct {
+	Name         string `json:"name"`
+	BaseAddress  HexInt `json:"base"`
+	Size         HexInt `json:"size"`
+	Entry        HexInt `json:"entry"`
+	SectionCount int    `json:"sectionCount"`
+	Path         string `json:"path"`
+}
+
+// moduleSectionInfo is Module/Section, Module/SectionList returns an array of it:
+//
+//	{"name":".text","address":"0x401000","size":"0x1000"}
+type moduleSectionInfo struct {
+	Name    string `json:"name"`
+	Address HexInt `json:"address"`
+	Size    HexInt `json:"size"`
+}
+
+// moduleExport is one entry of Module/Exports, forwardName is only set when forwarded is true:
+//
+//	{"ordinal":"0x1","rva":"0x1000","va":"0x401000","forwarded":false,"forwardName":"","name":"Foo","undecoratedName":"Foo"}
+type moduleExport struct {
+	Ordinal         HexInt `json:"ordinal"`
+	Rva             HexInt `json:"rva"`
+	Va              HexInt `json:"va"`
+	Forwarded       bool   `json:"forwarded"`
+	ForwardName     string `json:"forwardName"`
+	Name            string `json:"name"`
+	UndecoratedName string `json:"undecoratedName"`
+}
+
+// moduleImport is one entry of Module/Imports, ordinal is 0xffffffffffffffff for imports by name:
+//
+//	{"iatRva":"0x2000","iatVa":"0x402000","ordinal":"0x0","name":"CreateFileW","undecoratedName":"CreateFileW"}
+type moduleImport struct {
+	IatRva          HexInt `json:"iatRva"`
+	IatVa           HexInt `json:"iatVa"`
+	Ordinal         HexInt `json:"ordinal"`
+	Name            string `json:"name"`
+	UndecoratedName string `json:"undecoratedName"`
+}
+
+// Breakpoint is one entry of Breakpoint/List, the BRIDGEBP of bridgemain.h. Address is the exception code
+// for exception breakpoints, dll breakpoints are identified by Module.
+//
+//	{"type":2,"addr":"0x401000","enabled":true,"singleshoot":false,"active":true,"name":"","mod":"a.exe","slot":0,
+//	 "typeEx":1,"hwSize":2,"hitCount":0,"fastResume":false,"silent":false,"breakCondition":"","logText":"",
+//	 "logCondition":"","commandText":"","commandCondition":""}
+type Breakpoint struct {
+	Kind             BreakpointKind `json:"type"`
+	Address          HexInt         `json:"addr"`
+	Enabled          bool           `json:"enabled"`
+	Singleshot       bool           `json:"singleshoot"`
+	Active           bool           `json:"active"`
+	Name             string         `json:"name"`
+	Module           string         `json:"mod"`
+	Slot             int            `json:"slot"`
+	TypeEx           uint8          `json:"typeEx"`
+	HardwareSize     HardwareSize   `json:"hwSize"`
+	HitCount         int            `json:"hitCount"`
+	FastResume       bool           `json:"fastResume"`
+	Silent           bool           `json:"silent"`
+	BreakCondition   string         `json:"breakCondition"`
+	LogText          string         `json:"logText"`
+	LogCondition     string         `json:"logCondition"`
+	CommandText      string         `json:"commandText"`
+	CommandCondition string         `json:"commandCondition"`
+}
+
+// DebugEvent is one Server-Sent Event of /Events, the "data:" line of each event is one JSON object.
+// Which fields are set depends on Kind: Address is the breakpoint, exception, dll base, thread start
+// or the CIP after pausing and stepping, Code is the exception code or the exit code of a thread or
+// the process.
+//
+//	{"type":"breakpoint","addr":"0x401000","threadId":4242,"breakpoint":{"type":1,"addr":"0x401000",...}}
+//	{"type":"exception","addr":"0x401005","threadId":4242,"code":"0xc0000005","firstChance":true}
+//	{"type":"loadDll","addr":"0x7ffd3e0a0000","module":"kernel32.dll"}
+type DebugEvent struct {
+	Kind        DebugEventKind `json:"type"`
+	Address     HexInt         `json:"addr"`
+	ThreadID    int            `json:"threadId"`
+	Code        HexInt         `json:"code"`
+	FirstChance bool           `json:"firstChance"`
+	Module      string         `json:"module"`
+	Breakpoint  *Breakpoint    `json:"breakpoint"`
+}
+
+// ThreadInfo is one entry of Thread/List, the THREADALLINFO of bridgemain.h. Current marks the thread
+// x64dbg shows registers and the stack of:
+//
+//	{"number":0,"id":4242,"handle":"0x1a4","teb":"0x2d4000","start":"0x7ff6a1b21000","cip":"0x7ffd3f94d5c4",
+//	 "suspendCount":0,"priority":0,"waitReason":6,"lastError":"0x0","name":"Main Thread","current":true}
+type ThreadInfo struct {
+	Number       int            `json:"number"`
+	ID           int            `json:"id"`
+	Handle       HexInt         `json:"handle"`
+	Teb          HexInt         `json:"teb"`
+	StartAddress HexInt         `json:"start"`
+	Cip          HexInt         `json:"cip"`
+	SuspendCount int            `json:"suspendCount"`
+	Priority     ThreadPriority `json:"priority"`
+	WaitReason   int            `json:"waitReason"`
+	LastError    HexInt         `json:"lastError"`
+	Name         string         `json:"name"`
+	Current      bool           `json:"current"`
+}
+
+// MemoryRegion is one entry of Memory/Map, the MEMPAGE of bridgemain.h. Info is the owning module,
+// section or what x64dbg knows about the region ("Thread 1234 Stack", "PEB"). State, type and
+// protections are the raw MEMORY_BASIC_INFORMATION numbers:
+//
+//	{"base":"0x7ff6a1b21000","allocationBase":"0x7ff6a1b20000","size":"0x1000","state":4096,"type":16777216,
+//	 "allocationProtect":128,"protect":32,"info":".text"}
+type MemoryRegion struct {
+	BaseAddress       HexInt         `json:"base"`
+	AllocationBase    HexInt         `json:"allocationBase"`
+	Size              HexInt         `json:"size"`
+	State             PageState      `json:"state"`
+	Type              PageType       `json:"type"`
+	AllocationProtect PageProtection `json:"allocationProtect"`
+	Protect           PageProtection `json:"protect"`
+	Info              string         `json:"info"`
+}
+
+// Xref is one entry of Xref/Get and References. Xref/Get reads the xref database of x64dbg's analysis
+// and fills Type. References runs a reference search (reffind, refstr, modcallfind) and reads the
+// rows of the reference view back, Text is the last column past the disassembly: the string of
+// refstr, the destination of modcallfind:
+//
+//	{"address":"0x7ff6a1b21010","type":"call","disassembly":"call 0x7FF6A1B21100","text":""}
+//	{"address":"0x7ff6a1b21003","type":"","disassembly":"lea rcx, qword ptr ds:[0x7FF6A1B23000]","text":"\"hello\""}
+type Xref struct {
+	Address     HexInt   `json:"address"`
+	Type        XrefType `json:"type"`
+	Disassembly string   `json:"disassembly"`
+	Text        string   `json:"text"`
+}
+
+// SymbolInfo is Symbol/FromAddress, Symbol/Resolve and one entry of Symbol/List: the symbol at or before
+// the asked address, Displacement is the distance to it and 0 in a list. Base is the module base:
+//
+//	{"address":"0x7ffd3e0b5a10","module":"kernel32.dll","base":"0x7ffd3e0a0000","decorated":"CreateFileW",
+//	 "undecorated":"CreateFileW","displacement":"0x12","type":"export","ordinal":200}
+type SymbolInfo struct {
+	Address      HexInt     `json:"address"`
+	Module       string     `json:"module"`
+	Base         HexInt     `json:"base"`
+	Decorated    string     `json:"decorated"`
+	Undecorated  string     `json:"undecorated"`
+	Displacement HexInt     `json:"displacement"`
+	Type         SymbolType `json:"type"`
+	Ordinal      int        `json:"ordinal"`
+}
+
+// StackFrame is one entry of Stack/CallStack, what x64dbg's call stack view shows. Address is the
+// frame pointer, the stack address StackWalk found the frame at, From the code address in the frame
+// and To the return address. Module, Symbol and Displacement locate From, Symbol is empty when the
+// module has none there:
+//
+//	{"address":"0x14fe28","from":"0x7ffd3e0b5a22","to":"0x7ff6a1b2104a","module":"kernel32.dll",
+//	 "symbol":"CreateFileW","displacement":"0x12","comment":"return to a.exe.7FF6A1B2104A from kernel32.CreateFileW"}
+type StackFrame struct {
+	StackAddress  HexInt `json:"address"`
+	Address       HexInt `json:"from"`
+	ReturnAddress HexInt `json:"to"`
+	Module        string `json:"module"`
+	Symbol        string `json:"symbol"`
+	Displacement  HexInt `json:"displacement"`
+	Comment       string `json:"comment"`
+}
+
+// StackSlot is one entry of Stack/Dump: a pointer sized slot of the stack, the comment of the stack
+// view and, when Value points into a module, its symbol as "module!name+0x12":
+//
+//	{"address":"0x14fe28","value":"0x7ff6a1b2104a","comment":"return to a.exe.7FF6A1B2104A from ???","symbol":"a.exe!main+0x4a"}
+type StackSlot struct {
+	Address HexInt `json:"address"`
+	Value   HexInt `json:"value"`
+	Comment string `json:"comment"`
+	Symbol  string `json:"symbol"`
+}
+
+// registerContext is Register/Context, the REGDUMP of DbgGetRegDumpEx in one answer. The names are
+// x64dbg's C aliases, Bits tells what they are: 64 for x64dbg with r8-r15, 32 for x32dbg without.
+// Vectors and x87 registers are their memory image, lowest byte first. REGDUMP has no AVX-512 state,
+// the plugin moves zmm and k out through a scratch page, avx512 is false and they are empty when the
+// cpu or x64dbg has no AVX-512:
+//
+//	{"bits":64,"cax":"0x1","ccx":"0x0",...,"r15":"0x0","cip":"0x7ff6a1b21000","cflags":"0x246","gs":"0x2b",...,
+//	 "dr7":"0x0","mxcsr":"0x1f80","x87":{"control":"0x27f","status":"0x0","tag":"0xffff","st":["00000000000000000000",...]},
+//	 "xmm":["000000000000f03f0000000000000040",...],"ymm":[...],"avx512":true,"zmm":[...],"k":["0xffff",...]}
+type registerContext struct {
+	Bits   int    `json:"bits"`
+	CAX    HexInt `json:"cax"`
+	CCX    HexInt `json:"ccx"`
+	CDX    HexInt `json:"cdx"`
+	CBX    HexInt `json:"cbx"`
+	CSP    HexInt `json:"csp"`
+	CBP    HexInt `json:"cbp"`
+	CSI    HexInt `json:"csi"`
+	CDI    HexInt `json:"cdi"`
+	R8     HexInt `json:"r8"`
+	R9     HexInt `json:"r9"`
+	R10    HexInt `json:"r10"`
+	R11    HexInt `json:"r11"`
+	R12    HexInt `json:"r12"`
+	R13    HexInt `json:"r13"`
+	R14    HexInt `json:"r14"`
+	R15    HexInt `json:"r15"`
+	CIP    HexInt `json:"cip"`
+	CFLAGS HexInt `json:"cflags"`
+	GS     HexInt `json:"gs"`
+	FS     HexInt `json:"fs"`
+	ES     HexInt `json:"es"`
+	DS     HexInt `json:"ds"`
+	CS     HexInt `json:"cs"`
+	SS     HexInt `json:"ss"`
+	DR0    HexInt `json:"dr0"`
+	DR1    HexInt `json:"dr1"`
+	DR2    HexInt `json:"dr2"`
+	DR3    HexInt `json:"dr3"`
+	DR6    HexInt `json:"dr6"`
+	DR7    HexInt `json:"dr7"`
+	MXCSR  HexInt `json:"mxcsr"`
+	X87    struct {
+		Control HexInt     `json:"control"`
+		Status  HexInt     `json:"status"`
+		Tag     HexInt     `json:"tag"`
+		ST      []HexBytes `json:"st"`
+	} `json:"x87"`
+	XMM    []HexBytes `json:"xmm"`
+	YMM    []HexBytes `json:"ymm"`
+	AVX512 bool       `json:"avx512"`
+	ZMM    []HexBytes `json:"zmm"`
+	K      []HexInt   `json:"k"`
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// contract decodes a recorded plugin response from testdata/plugin the same way request does.
+func contract[T Type](t *testing.T, file string, want T) {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "plugin", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got T
+	if err := decode(&got, body); err != nil {
+		t.Fatalf("%s: %v", file, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s:\n got %+v\nwant %+v", file, got, want)
+	}
+}
+
+func TestWireSchemaContract(t *testing.T) {
+	contract(t, "Assembler_Assemble.json", assemblerResult{Success: true, Size: 3, Data: HexBytes{0x48, 0x89, 0xc8}})
+	contract(t, "Disasm_GetInstruction.json", disassemblerAddress{Address: 0x7ff6a1b21000, Instruction: "lea rcx, qword ptr ds:[0x7FF6A1B23000]", Size: 7})
+	contract(t, "Disasm_GetInstructionRange.json", []disassemblerAddress{
+		{Address: 0x401000, Instruction: "push ebp", Size: 1},
+		{Address: 0x401001, Instruction: "mov ebp, esp", Size: 2},
+		{Address: 0x401003, Instruction: `push "abc"`, Size: 5},
+	})
+	contract(t, "Disasm_GetInstructionAtRIP.json", disassembleRip{Rip: 0x7ff6a1b21000, Instruction: "sub rsp, 0x28", Size: 4})
+	contract(t, "Disasm_StepInWithDisasm.json", disassembleRipWithSetupIn{StepResult: "Step in executed", Rip: 0x7ff6a1b21004, Instruction: "call 0x7FF6A1B21100", Size: 5})
+	contract(t, "MemoryBase.json", memoryBase{BaseAddress: 0x7ff6a1b20000, Size: 0x1000})
+	contract(t, "GetModuleList.json", []moduleInfo{
+		{Name: "a.exe", BaseAddress: 0x7ff6a1b20000, Size: 0x9000, Entry: 0x7ff6a1b21000, SectionCount: 6, Path: `C:\Users\dev\a.exe`},
+		{Name: "ntdll.dll", BaseAddress: 0x7ffd3f8b0000, Size: 0x1f8000, Entry: 0, SectionCount: 9, Path: `C:\Windows\System32\ntdll.dll`},
+		{Name: "ntoskrnl.exe", BaseAddress: 0xfffff80000000000, Size: 0x1046000, Entry: 0xfffff800003ab010, SectionCount: 27, Path: `C:\Windows\System32\ntoskrnl.exe`},
+	})
+	contract(t, "Module_Info.json", moduleInfo{Name: "kernel32.dll", BaseAddress: 0x7ffd3e0a0000, Size: 0xbf000, Entry: 0x7ffd3e0b7c70, SectionCount: 7, Path: `C:\Windows\System32\kernel32.dll`})
+	contract(t, "Module_SectionList.json", []moduleSectionInfo{
+		{Name: ".text", Address: 0x7ffd3e0a1000, Size: 0x7f000},
+		{Name: ".rdata", Address: 0x7ffd3e120000, Size: 0x33000},
+	})
+	contract(t, "Module_Exports.json", []moduleExport{
+		{Ordinal: 1, Rva: 0x1f0d0, Va: 0x7ffd3e0bf0d0, Name: "AcquireSRWLockExclusive", UndecoratedName: "AcquireSRWLockExclusive"},
+		{Ordinal: 0x2d8, Forwarded: true, ForwardName: "NTDLL.RtlAllocateHeap", Name: "HeapAlloc", UndecoratedName: "HeapAlloc"},
+	})
+	contract(t, "Module_Imports.json", []moduleImport{
+		{IatRva: 0x80000, IatVa: 0x7ffd3e120000, Ordinal: ^HexInt(0), Name: "RtlAllocateHeap", UndecoratedName: "RtlAllocateHeap"},
+		{IatRva: 0x80008, IatVa: 0x7ffd3e120008, Ordinal: 7},
+	})
+	contract(t, "Breakpoint_List.json", []Breakpoint{
+		{Kind: BreakpointNormal, Address: 0x7ff6a1b21000, Enabled: true, Active: true, Name: "entry", Module: "a.exe", HitCount: 3, BreakCondition: "rcx==0", LogText: "rcx={rcx}"},
+		{Kind: BreakpointHardware, Address: 0x7ff6a1b23000, Active: true, Module: "a.exe", Slot: 1, TypeEx: uint8(HardwareWrite), HardwareSize: HardwareQword, Silent: true},
+	})
+	contract(t, "Memory_Map.json", []MemoryRegion{
+		{BaseAddress: 0x10000, Size: 0x10000, State: PageFree, Protect: PageNoAccess},
+		{BaseAddress: 0x2d4000, AllocationBase: 0x2d4000, Size: 0x2000, State: PageCommit, Type: PagePrivate, AllocationProtect: PageReadWrite, Protect: PageReadWrite | PageGuard, Info: "Thread 4242 Stack"},
+		{BaseAddress: 0x7ff6a1b21000, AllocationBase: 0x7ff6a1b20000, Size: 0x1000, State: PageCommit, Type: PageImage, AllocationProtect: PageExecuteWriteCopy, Protect: PageExecuteRead, Info: ".text"},
+	})
+	contract(t, "Thread_List.json", []ThreadInfo{
+		{ID: 4242, Handle: 0x1a4, Teb: 0x2d4000, StartAddress: 0x7ff6a1b21000, Cip: 0x7ffd3f94d5c4, WaitReason: 6, Name: "Main Thread", Current: true},
+		{Number: 1, ID: 5150, Handle: 0x1b0, Teb: 0x2d6000, StartAddress: 0x7ffd3f8e2680, Cip: 0x7ffd3f950a14, SuspendCount: 1, Priority: ThreadPriorityIdle, WaitReason: 15, LastError: 0x57},
+	})
+	contract(t, "Xref_Get.json", []Xref{
+		{Address: 0x7ff6a1b21010, Type: XrefCall, Disassembly: "call 0x7FF6A1B21100"},
+		{Address: 0x7ff6a1b21040, Type: XrefJump, Disassembly: "jmp 0x7FF6A1B21100"},
+	})
+	contract(t, "References.json", []Xref{
+		{Address: 0x7ff6a1b21003, Disassembly: "lea rcx, qword ptr ds:[0x7FF6A1B23000]", Text: "\"hello\\n\""},
+		{Address: 0x7ff6a1b21010, Disassembly: "call qword ptr ds:[<&GetStdHandle>]", Text: "kernel32.GetStdHandle"},
+	})
+	contract(t, "Symbol_FromAddress.json", SymbolInfo{Address: 0x7ffd3e0b5a10, Module: "kernel32.dll", Base: 0x7ffd3e0a0000, Decorated: "CreateFileW", Undecorated: "CreateFileW", Displacement: 0x12, Type: SymbolExport, Ordinal: 200})
+	contract(t, "Symbol_List.json", []SymbolInfo{
+		{Address: 0x7ffd3e0b5a10, Module: "kernel32.dll", Base: 0x7ffd3e0a0000, Decorated: "CreateFileW", Undecorated: "CreateFileW", Type: SymbolExport, Ordinal: 200},
+		{Address: 0x7ffd3e120000, Module: "kernel32.dll", Base: 0x7ffd3e0a0000, Decorated: "RtlAllocateHeap", Undecorated: "RtlAllocateHeap", Type: SymbolImport},
+		{Address: 0x7ffd3e0a1230, Module: "kernel32.dll", Base: 0x7ffd3e0a0000, Decorated: "?BaseDllInit@@YAHPEAX@Z", Undecorated: "int __cdecl BaseDllInit(void *)", Type: SymbolDebug},
+	})
+	contract(t, "Stack_CallStack.json", []StackFrame{
+		{StackAddress: 0x14fe28, Address: 0x7ffd3e0b5a22, ReturnAddress: 0x7ff6a1b2104a, Module: "kernel32.dll", Symbol: "CreateFileW", Displacement: 0x12, Comment: "return to a.exe.7FF6A1B2104A from kernel32.CreateFileW"},
+		{StackAddress: 0x14fe80, Address: 0x7ff6a1b2104a, ReturnAddress: 0x7ffd3e0b7c80, Module: "a.exe", Comment: "return to kernel32.BaseThreadInitThunk+10 from a.exe.7FF6A1B2104A"},
+	})
+	wantContext := registerContext{
+		Bits: 32, CAX: 1, CCX: 0x77a10000, CSP: 0x19ff70, CIP: 0x401000, CFLAGS: 0x246,
+		GS: 0x2b, FS: 0x53, ES: 0x2b, DS: 0x2b, CS: 0x23, SS: 0x2b, DR0: 0x403000, DR7: 1, MXCSR: 0x1f80,
+		XMM: make([]HexBytes, 8), YMM: make([]HexBytes, 8), ZMM: []HexBytes{}, K: []HexInt{},
+	}
+	wantContext.X87.Control, wantContext.X87.Status, wantContext.X87.Tag = 0x27f, 0x3800, 0x3fff
+	wantContext.X87.ST = make([]HexBytes, 8)
+	for i := range 8 {
+		wantContext.X87.ST[i] = make(HexBytes, 10)
+		wantContext.XMM[i] = make(HexBytes, 16)
+		wantContext.YMM[i] = make(HexBytes, 32)
+	}
+	wantContext.X87.ST[0] = HexBytes{0, 0, 0, 0, 0, 0, 0, 0x80, 0xff, 0x3f}
+	wantContext.XMM[0] = HexBytes{0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}
+	wantContext.XMM[1] = HexBytes{0, 0, 0x80, 0x3f, 0, 0, 0, 0x40, 0, 0, 0x40, 0x40, 0, 0, 0x80, 0x40}
+	wantContext.YMM[0] = append(slices.Clone(wantContext.XMM[0]), 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x7f)
+	contract(t, "Register_Context32.json", wantContext)
+	contract(t, "Stack_Dump.json", []StackSlot{
+		{Address: 0x14fe20},
+		{Address: 0x14fe28, Value: 0x7ff6a1b2104a, Comment: "return to a.exe.7FF6A1B2104A from ???", Symbol: "a.exe!main+0x4a"},
+		{Address: 0x14fe30, Value: 0x7ff6a1b23000, Comment: "a.exe.7FF6A1B23000 \"hello\\n\"", Symbol: "a.exe!message"},
+	})
+}
+
+func TestWireSchemaVersion(t *testing.T) {
+	version := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if version != "" {
+			w.Header().Set(wireSchemaHeader, version)
+		}
+		w.Write([]byte(`{"base_address":"0x400000","size":"0x1000"}`))
+	}))
+	defer srv.Close()
+	x := NewClient(srv.URL).X64dbg()
+
+	for _, v := range []string{"", strconv.Itoa(WireSchemaVersion)} {
+		version = v
+		if _, err := x.Memory.TryFindBaseByAddress(0x401000); err != nil {
+			t.Errorf("schema %q: %v", v, err)
+		}
+	}
+	version = strconv.Itoa(WireSchemaVersion + 1)
+	if _, err := x.Memory.TryFindBaseByAddress(0x401000); !errors.Is(err, ErrSchemaVersion) {
+		t.Errorf("schema %s: %v, want ErrSchemaVersion", version, err)
+	}
+}
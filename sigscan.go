@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+// ScanReaderAt reads this much per ReadAt
+const sigScanChunk = 1024 * 1024
+
+// SignatureMatch is one hit of a Scanner, Index is the matching pattern and Offset where it starts,
+// counted from the base of the scan: 0 for bytes and files, the address for debuggee memory.
+type SignatureMatch struct {
+	Index  int
+	Offset int64
+}
+
+// Scanner finds many masked patterns in one pass, a Horspool scan whose window is the shortest
+// pattern: the shift table is built from the first min length bytes of every pattern, wildcards
+// and nibble masks included, so each window is looked at once for all patterns together.
+type Scanner struct {
+	patterns []Pattern
+	window   int // length of the shortest pattern
+	longest  int
+	shift    [256]int
+}
+
+func NewScanner(patterns ...Pattern) (*Scanner, error) {
+	s := &Scanner{}
+	for i, p := range patterns {
+		if len(p.Bytes) == 0 || len(p.Mask) != len(p.Bytes) {
+			return nil, fmt.Errorf("x64dbg: scanner: pattern %d: %d bytes with %d mask bytes", i, len(p.Bytes), len(p.Mask))
+		}
+		// 不在掩码内的位一律清零，Match 才不会永远失败
+		q := Pattern{Bytes: make([]byte, len(p.Bytes)), Mask: p.Mask}
+		for j := range p.Bytes {
+			q.Bytes[j] = p.Bytes[j] & p.Mask[j]
+		}
+		s.patterns = append(s.patterns, q)
+		if s.window == 0 || q.Len() < s.window {
+			s.window = q.Len()
+		}
+		s.longest = max(s.longest, q.Len())
+	}
+	for c := range s.shift {
+		s.shift[c] = s.window
+	}
+	for _, p := range s.patterns {
+		for i := 0; i < s.window-1; i++ {
+			for c := range s.shift {
+				if byte(c)&p.Mask[i] == p.Bytes[i] {
+					s.shift[c] = min(s.shift[c], s.window-1-i)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+// ParseSignatures is NewScanner over ParsePattern of every signature.
+func ParseSignatures(signatures ...string) (*Scanner, error) {
+	patterns := make([]Pattern, 0, len(signatures))
+	for _, signature := range signatures {
+		p, err := ParsePattern(signature)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return NewScanner(patterns...)
+}
+
+func (s *Scanner) Patterns() []Pattern { return s.patterns }
+
+// scan calls yield for the matches in data starting before limit, in order of offset and pattern.
+func (s *Scanner) scan(data []byte, limit int, yield func(index int, offset int) bool) bool {
+	if len(s.patterns) == 0 {
+		return true
+	}
+	for pos := 0; pos < limit && pos+s.window <= len(data); pos += s.shift[data[pos+s.window-1]] {
+		for i, p := range s.patterns {
+			if p.Match(data[pos:]) && !yield(i, pos) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Scan yields every match in data.
+func (s *Scanner) Scan(data []byte) iter.Seq[SignatureMatch] {
+	return func(yield func(SignatureMatch) bool) {
+		s.scan(data, len(data), func(index int, offset int) bool {
+			return yield(SignatureMatch{Index: index, Offset: int64(offset)})
+		})
+	}
+}
+
+// ScanReaderAt yields the matches in the first size bytes of r, read in chunks that overlap by the
+// longest pattern so a match across a chunk border is found once. Offsets are counted from base.
+func (s *Scanner) ScanReaderAt(r io.ReaderAt, base int64, size int64) iter.Seq2[SignatureMatch, error] {
+	return func(yield func(SignatureMatch, error) bool) {
+		overlap := int64(max(s.longest-1, 0))
+		buf := make([]byte, sigScanChunk+overlap)
+		for start := int64(0); start < size; {
+			n := min(int64(len(buf)), size-start)
+			read, err := r.ReadAt(buf[:n], start)
+			if err != nil && !(errors.Is(err, io.EOF) && int64(read) == n) {
+				yield(SignatureMatch{}, err)
+				return
+			}
+			// 最后一块扫到底，其余块末尾的 overlap 留给下一块
+			limit := n
+			if start+n < size {
+				limit = n - overlap
+			}
+			if !s.scan(buf[:n], int(limit), func(index int, offset int) bool {
+				return yield(SignatureMatch{Index: index, Offset: base + start + int64(offset)}, nil)
+			}) {
+				return
+			}
+			start += limit
+		}
+	}
+}
+
+// ScanFile scans a file on disk, a dump saved with memory.SaveToFile for example.
+func (s *Scanner) ScanFile(path string) iter.Seq2[SignatureMatch, error] {
+	return func(yield func(SignatureMatch, error) bool) {
+		f, err := os.Open(path)
+		if err != nil {
+			yield(SignatureMatch{}, err)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			yield(SignatureMatch{}, err)
+			return
+		}
+		for m, err := range s.ScanReaderAt(f, 0, info.Size()) {
+			if !yield(m, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ScanLocal reads scope through a MemoryReader and runs s over it in this process instead of the
+// plugin, Offset is the address. Unreadable pages are skipped, a match touching one is dropped.
+func (p pattern) ScanLocal(ctx context.Context, scope PatternScope, s *Scanner) iter.Seq2[SignatureMatch, error] {
+	return func(yield func(SignatureMatch, error) bool) {
+		ranges, err := scope.ranges(ctx, p.client)
+		if err != nil {
+			yield(SignatureMatch{}, err)
+			return
+		}
+		for _, rg := range ranges {
+			r := memory{p.client}.NewReader(rg.start, int64(rg.size))
+			r.ZeroFill = true
+			for m, err := range s.ScanReaderAt(readerAtContext{ctx, r}, int64(rg.start), int64(rg.size)) {
+				if err == nil && touchesHole(r.Holes(), m.Offset, s.patterns[m.Index].Len()) {
+					continue
+				}
+				if !yield(m, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+type readerAtContext struct {
+	ctx context.Context
+	r   *MemoryReader
+}
+
+func (r readerAtContext) ReadAt(p []byte, off int64) (int, error) {
+	return r.r.ReadAtContext(r.ctx, p, off)
+}
+
+func touchesHole(holes []MemoryHole, address int64, size int) bool {
+	for _, h := range holes {
+		if int64(h.Address) < address+int64(size) && address < int64(h.Address)+int64(h.Size) {
+			return true
+		}
+	}
+	return false
+}
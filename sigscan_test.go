@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// naiveScan is the reference: every pattern at every offset.
+func naiveScan(patterns []Pattern, data []byte) []SignatureMatch {
+	var all []SignatureMatch
+	for off := range data {
+		for i, p := range patterns {
+			if p.Match(data[off:]) {
+				all = append(all, SignatureMatch{Index: i, Offset: int64(off)})
+			}
+		}
+	}
+	return all
+}
+
+func TestScanner(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	data := make([]byte, 2*sigScanChunk+0x3456)
+	for i := range data {
+		data[i] = byte(rng.IntN(16)) // 小字母表，随机命中才够多
+	}
+	signatures := []string{
+		"01 02 03 04 05 06 07 08",
+		"0A ?? 0B",
+		"0? ?1 0F 0E",
+		"0C",
+		"?? 0D ?? 0D ?? 0D ?? 0D ?? 0D",
+	}
+	s, err := ParseSignatures(signatures...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 跨块边界放一处最长的
+	for _, off := range []int{0, sigScanChunk - 4, 2*sigScanChunk - 9, len(data) - 8} {
+		copy(data[off:], []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	}
+	want := naiveScan(s.Patterns(), data)
+
+	if got := slices.Collect(s.Scan(data)); !slices.Equal(got, want) {
+		t.Errorf("Scan: %d matches, want %d", len(got), len(want))
+	}
+
+	var got []SignatureMatch
+	for m, err := range s.ScanReaderAt(bytes.NewReader(data), 0x1000, int64(len(data))) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Offset -= 0x1000
+		got = append(got, m)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ScanReaderAt: %d matches, want %d", len(got), len(want))
+		for i := range min(len(got), len(want)) {
+			if got[i] != want[i] {
+				t.Fatalf("first difference at %d: %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.bin")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for m, err := range s.ScanFile(path) {
+		if err != nil || m != want[n] {
+			t.Fatalf("ScanFile match %d: %+v %v, want %+v", n, m, err, want[n])
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+	for _, err := range s.ScanFile(filepath.Join(t.TempDir(), "missing.bin")) {
+		if err == nil {
+			t.Error("missing file scanned")
+		}
+	}
+
+	// 掩码外的位被忽略
+	loose, err := NewScanner(Pattern{Bytes: []byte{0x4f, 0xff}, Mask: []byte{0xf0, 0xff}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(loose.Scan([]byte{0x00, 0x41, 0xff})); len(got) != 1 || got[0].Offset != 1 {
+		t.Errorf("nibble mask: %+v", got)
+	}
+	if _, err := NewScanner(Pattern{Bytes: []byte{1, 2}, Mask: []byte{0xff}}); err == nil {
+		t.Error("mask length mismatch accepted")
+	}
+	if _, err := ParseSignatures("E8 ?? X"); err == nil {
+		t.Error("bad signature accepted")
+	}
+	empty, _ := NewScanner()
+	if got := slices.Collect(empty.Scan(data)); len(got) != 0 {
+		t.Errorf("empty scanner: %+v", got)
+	}
+}
+
+func TestScanLocal(t *testing.T) {
+	f := newFakeMemory(0x4000, 0x402000)
+	p := f.client(t).Pattern
+
+	inside := Pattern{Bytes: f.image[0x100:0x108], Mask: bytes.Repeat([]byte{0xff}, 8)}
+	wild := Pattern{Bytes: []byte{0, 0, 0, 0}, Mask: []byte{0, 0, 0, 0}}
+	s, err := NewScanner(inside, wild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []SignatureMatch
+	for _, m := range naiveScan(s.Patterns(), f.image) {
+		m.Offset += int64(f.base)
+		if !touchesHole([]MemoryHole{{Address: 0x402000, Size: memoryPageSize}}, m.Offset, s.Patterns()[m.Index].Len()) {
+			want = append(want, m)
+		}
+	}
+	var got []SignatureMatch
+	for m, err := range p.ScanLocal(t.Context(), ScanRange(f.base, len(f.image)), s) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m)
+	}
+	if !slices.Equal(got, want) || !slices.Contains(got, SignatureMatch{Offset: 0x400100}) {
+		t.Errorf("ScanLocal: %d matches, want %d", len(got), len(want))
+	}
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// Float32x4 views the register as four packed floats, [0] in the lowest bytes like movups loads them.
+func (v Vector128) Float32x4() (f [4]float32) {
+	for i := range f {
+		f[i] = math.Float32frombits(binary.LittleEndian.Uint32(v[i*4:]))
+	}
+	return f
+}
+
+// Float64x2 views the register as two packed doubles.
+func (v Vector128) Float64x2() (f [2]float64) {
+	for i := range f {
+		f[i] = math.Float64frombits(binary.LittleEndian.Uint64(v[i*8:]))
+	}
+	return f
+}
+
+func VectorFromFloat32x4(f [4]float32) (v Vector128) {
+	for i, x := range f {
+		binary.LittleEndian.PutUint32(v[i*4:], math.Float32bits(x))
+	}
+	return v
+}
+
+func VectorFromFloat64x2(f [2]float64) (v Vector128) {
+	for i, x := range f {
+		binary.LittleEndian.PutUint64(v[i*8:], math.Float64bits(x))
+	}
+	return v
+}
+
+// Lanes splits a YMM register into its 128 bit lanes, Lanes()[0] is the XMM register.
+func (v Vector256) Lanes() (l [2]Vector128) {
+	for i := range l {
+		l[i] = Vector128(v[i*16:])
+	}
+	return l
+}
+
+// Lanes splits a ZMM register into its 128 bit lanes.
+func (v Vector512) Lanes() (l [4]Vector128) {
+	for i := range l {
+		l[i] = Vector128(v[i*16:])
+	}
+	return l
+}
+
+// 80 位扩展精度：64 位尾数带显式整数位，15 位指数偏置 16383，最高位是符号
+const float80Bias = 16383
+
+// Float64 rounds the x87 value to a float64, values out of its range become ±Inf or 0.
+func (f Float80) Float64() float64 {
+	mant := binary.LittleEndian.Uint64(f[:8])
+	se := binary.LittleEndian.Uint16(f[8:])
+	exp := int(se & 0x7fff)
+	var v float64
+	switch {
+	case exp == 0x7fff && mant<<1 == 0:
+		v = math.Inf(1)
+	case exp == 0x7fff:
+		v = math.NaN()
+	case exp == 0: // 非规格化数和规格化数的最小指数同阶
+		v = math.Ldexp(float64(mant), 1-float80Bias-63)
+	default:
+		v = math.Ldexp(float64(mant), exp-float80Bias-63)
+	}
+	if se&0x8000 != 0 {
+		v = math.Copysign(v, -1)
+	}
+	return v
+}
+
+// NewFloat80 converts v exactly, every float64 is an 80 bit value.
+func NewFloat80(v float64) (f Float80) {
+	b := math.Float64bits(v)
+	se := uint16(b>>63) << 15
+	exp := int(b>>52) & 0x7ff
+	frac := b & (1<<52 - 1)
+	var mant uint64
+	switch {
+	case exp == 0x7ff:
+		se |= 0x7fff
+		mant = 1<<63 | frac<<11
+	case exp == 0 && frac == 0:
+	case exp == 0:
+		lz := bits.LeadingZeros64(frac)
+		mant = frac << lz
+		se |= uint16(-1074 - lz + float80Bias + 63)
+	default:
+		mant = 1<<63 | frac<<11
+		se |= uint16(exp - 1023 + float80Bias)
+	}
+	binary.LittleEndian.PutUint64(f[:8], mant)
+	binary.LittleEndian.PutUint16(f[8:], se)
+	return f
+}
+
+func (f Float80) String() string { return strconv.FormatFloat(f.Float64(), 'g', -1, 64) }
+
+// readImage reads the memory image of register name, which must fill out.
+func (m RegisterManager) readImage(ctx context.Context, endpoint string, name string, out []byte) error {
+	image, err := tryRequest[HexBytes](m.client, ctx, endpoint, map[string]string{"register": name})
+	if err != nil {
+		return err
+	}
+	if len(image) != len(out) {
+		return &DecodeError{Endpoint: endpoint, Type: fmt.Sprintf("[%d]byte", len(out)), Body: hex.EncodeToString(image), Err: fmt.Errorf("%d bytes", len(image))}
+	}
+	copy(out, image)
+	return nil
+}
+
+func (m RegisterManager) writeImage(ctx context.Context, endpoint string, name string, image []byte) error {
+	_, err := tryPost[bool](m.client, ctx, endpoint, map[string]string{"register": name}, hex.EncodeToString(image))
+	return err
+}
+
+// numbered is the name of register n of a file of count, xmm0-31 and friends.
+func numbered(prefix string, n, count int) (string, error) {
+	if n < 0 || n >= count {
+		return "", fmt.Errorf("x64dbg: %s%d: there are %s0 to %s%d", prefix, n, prefix, prefix, count-1)
+	}
+	return prefix + strconv.Itoa(n), nil
+}
+
+func (m RegisterManager) vector(ctx context.Context, prefix string, n, count int, out []byte) error {
+	name, err := numbered(prefix, n, count)
+	if err != nil {
+		return err
+	}
+	return m.readImage(ctx, "Register/Vector", name, out)
+}
+
+func (m RegisterManager) setVector(ctx context.Context, prefix string, n, count int, image []byte) error {
+	name, err := numbered(prefix, n, count)
+	if err != nil {
+		return err
+	}
+	return m.writeImage(ctx, "Register/Vector", name, image)
+}
+
+// GetXMM reads XMMn from x64dbg's register dump, x32dbg has XMM0 to XMM7. XMM16 and up are AVX-512
+// state the dump lacks, the plugin moves them through a scratch page in the debuggee with movdqu.
+func (m RegisterManager) GetXMM(n int) Vector128 {
+	return must(m.TryGetXMM(n))
+}
+func (m RegisterManager) TryGetXMM(n int) (Vector128, error) {
+	return m.GetXMMContext(context.Background(), n)
+}
+func (m RegisterManager) GetXMMContext(ctx context.Context, n int) (v Vector128, err error) {
+	err = m.vector(ctx, "xmm", n, 32, v[:])
+	return v, err
+}
+func (m RegisterManager) SetXMM(n int, v Vector128) { mylog.Check(m.TrySetXMM(n, v)) }
+func (m RegisterManager) TrySetXMM(n int, v Vector128) error {
+	return m.SetXMMContext(context.Background(), n, v)
+}
+func (m RegisterManager) SetXMMContext(ctx context.Context, n int, v Vector128) error {
+	return m.setVector(ctx, "xmm", n, 32, v[:])
+}
+
+// GetYMM reads YMMn from x64dbg's register dump, YMM16 and up like XMM16 and up.
+func (m RegisterManager) GetYMM(n int) Vector256 {
+	return must(m.TryGetYMM(n))
+}
+func (m RegisterManager) TryGetYMM(n int) (Vector256, error) {
+	return m.GetYMMContext(context.Background(), n)
+}
+func (m RegisterManager) GetYMMContext(ctx context.Context, n int) (v Vector256, err error) {
+	err = m.vector(ctx, "ymm", n, 32, v[:])
+	return v, err
+}
+func (m RegisterManager) SetYMM(n int, v Vector256) { mylog.Check(m.TrySetYMM(n, v)) }
+func (m RegisterManager) TrySetYMM(n int, v Vector256) error {
+	return m.SetYMMContext(context.Background(), n, v)
+}
+func (m RegisterManager) SetYMMContext(ctx context.Context, n int, v Vector256) error {
+	return m.setVector(ctx, "ymm", n, 32, v[:])
+}
+
+// GetZMM reads ZMMn with vmovdqu through a scratch page the plugin allocates in the debuggee for the
+// call, the debuggee's CPU needs AVX-512.
+func (m RegisterManager) GetZMM(n int) Vector512 {
+	return must(m.TryGetZMM(n))
+}
+func (m RegisterManager) TryGetZMM(n int) (Vector512, error) {
+	return m.GetZMMContext(context.Background(), n)
+}
+func (m RegisterManager) GetZMMContext(ctx context.Context, n int) (v Vector512, err error) {
+	err = m.vector(ctx, "zmm", n, 32, v[:])
+	return v, err
+}
+func (m RegisterManager) SetZMM(n int, v Vector512) { mylog.Check(m.TrySetZMM(n, v)) }
+func (m RegisterManager) TrySetZMM(n int, v Vector512) error {
+	return m.SetZMMContext(context.Background(), n, v)
+}
+func (m RegisterManager) SetZMMContext(ctx context.Context, n int, v Vector512) error {
+	return m.setVector(ctx, "zmm", n, 32, v[:])
+}
+
+// GetK reads the AVX-512 mask register Kn with kmovq, through a scratch page like GetZMM.
+func (m RegisterManager) GetK(n int) uint64 {
+	return must(m.TryGetK(n))
+}
+func (m RegisterManager) TryGetK(n int) (uint64, error) {
+	return m.GetKContext(context.Background(), n)
+}
+func (m RegisterManager) GetKContext(ctx context.Context, n int) (uint64, error) {
+	var image [8]byte
+	err := m.vector(ctx, "k", n, 8, image[:])
+	return binary.LittleEndian.Uint64(image[:]), err
+}
+func (m RegisterManager) SetK(n int, v uint64) { mylog.Check(m.TrySetK(n, v)) }
+func (m RegisterManager) TrySetK(n int, v uint64) error {
+	return m.SetKContext(context.Background(), n, v)
+}
+func (m RegisterManager) SetKContext(ctx context.Context, n int, v uint64) error {
+	return m.setVector(ctx, "k", n, 8, binary.LittleEndian.AppendUint64(nil, v))
+}
+
+// GetST reads ST(n), the x87 register n places below the top of the stack.
+func (m RegisterManager) GetST(n int) Float80 {
+	return must(m.TryGetST(n))
+}
+func (m RegisterManager) TryGetST(n int) (Float80, error) {
+	return m.GetSTContext(context.Background(), n)
+}
+func (m RegisterManager) GetSTContext(ctx context.Context, n int) (f Float80, err error) {
+	name, err := numbered("st", n, 8)
+	if err != nil {
+		return f, err
+	}
+	err = m.readImage(ctx, "Register/FPU", name, f[:])
+	return f, err
+}
+func (m RegisterManager) SetST(n int, f Float80) { mylog.Check(m.TrySetST(n, f)) }
+func (m RegisterManager) TrySetST(n int, f Float80) error {
+	return m.SetSTContext(context.Background(), n, f)
+}
+func (m RegisterManager) SetSTContext(ctx context.Context, n int, f Float80) error {
+	name, err := numbered("st", n, 8)
+	if err != nil {
+		return err
+	}
+	return m.writeImage(ctx, "Register/FPU", name, f[:])
+}
+
+func (m RegisterManager) GetMXCSR() uint32 {
+	return must(m.TryGetMXCSR())
+}
+func (m RegisterManager) TryGetMXCSR() (uint32, error) {
+	return m.GetMXCSRContext(context.Background())
+}
+func (m RegisterManager) GetMXCSRContext(ctx context.Context) (uint32, error) {
+	var image [4]byte
+	err := m.readImage(ctx, "Register/FPU", "mxcsr", image[:])
+	return binary.LittleEndian.Uint32(image[:]), err
+}
+func (m RegisterManager) SetMXCSR(v uint32) { mylog.Check(m.TrySetMXCSR(v)) }
+func (m RegisterManager) TrySetMXCSR(v uint32) error {
+	return m.SetMXCSRContext(context.Background(), v)
+}
+func (m RegisterManager) SetMXCSRContext(ctx context.Context, v uint32) error {
+	return m.writeImage(ctx, "Register/FPU", "mxcsr", binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func (m RegisterManager) GetX87ControlWord() uint16 {
+	return must(m.TryGetX87ControlWord())
+}
+func (m RegisterManager) TryGetX87ControlWord() (uint16, error) {
+	return m.GetX87ControlWordContext(context.Background())
+}
+func (m RegisterManager) GetX87ControlWordContext(ctx context.Context) (uint16, error) {
+	var image [2]byte
+	err := m.readImage(ctx, "Register/FPU", "x87cw", image[:])
+	return binary.LittleEndian.Uint16(image[:]), err
+}
+func (m RegisterManager) SetX87ControlWord(v uint16) { mylog.Check(m.TrySetX87ControlWord(v)) }
+func (m RegisterManager) TrySetX87ControlWord(v uint16) error {
+	return m.SetX87ControlWordContext(context.Background(), v)
+}
+func (m RegisterManager) SetX87ControlWordContext(ctx context.Context, v uint16) error {
+	return m.writeImage(ctx, "Register/FPU", "x87cw", binary.LittleEndian.AppendUint16(nil, v))
+}
+
+// GetX87StatusWord reads the x87 status word, bits 11-13 are TOP.
+func (m RegisterManager) GetX87StatusWord() uint16 {
+	return must(m.TryGetX87StatusWord())
+}
+func (m RegisterManager) TryGetX87StatusWord() (uint16, error) {
+	return m.GetX87StatusWordContext(context.Background())
+}
+func (m RegisterManager) GetX87StatusWordContext(ctx context.Context) (uint16, error) {
+	var image [2]byte
+	err := m.readImage(ctx, "Register/FPU", "x87sw", image[:])
+	return binary.LittleEndian.Uint16(image[:]), err
+}
+func (m RegisterManager) SetX87StatusWord(v uint16) { mylog.Check(m.TrySetX87StatusWord(v)) }
+func (m RegisterManager) TrySetX87StatusWord(v uint16) error {
+	return m.SetX87StatusWordContext(context.Background(), v)
+}
+func (m RegisterManager) SetX87StatusWordContext(ctx context.Context, v uint16) error {
+	return m.writeImage(ctx, "Register/FPU", "x87sw", binary.LittleEndian.AppendUint16(nil, v))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/hex"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestVectorViews(t *testing.T) {
+	v := VectorFromFloat32x4([4]float32{1, 2, 3, 4})
+	if hex.EncodeToString(v[:]) != "0000803f000000400000404000008040" || v.Float32x4() != [4]float32{1, 2, 3, 4} {
+		t.Errorf("float32x4: %x %v", v, v.Float32x4())
+	}
+	d := VectorFromFloat64x2([2]float64{1, -0.5})
+	if d.Float64x2() != [2]float64{1, -0.5} || d[7] != 0x3f {
+		t.Errorf("float64x2: %x %v", d, d.Float64x2())
+	}
+	var y Vector256
+	copy(y[16:], v[:])
+	if lanes := y.Lanes(); lanes[0] != (Vector128{}) || lanes[1] != v {
+		t.Errorf("YMM lanes: %x", lanes)
+	}
+
+	for _, f := range []float64{0, 1, -2.5, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, 0x1p-1050, math.Inf(-1)} {
+		if got := NewFloat80(f).Float64(); got != f {
+			t.Errorf("Float80 round trip of %v: %v", f, got)
+		}
+	}
+	if one := NewFloat80(1); hex.EncodeToString(one[:]) != "0000000000000080ff3f" || one.String() != "1" {
+		t.Errorf("Float80(1) = %x", one)
+	}
+	if !math.IsNaN(NewFloat80(math.NaN()).Float64()) || !math.Signbit(NewFloat80(math.Copysign(0, -1)).Float64()) {
+		t.Error("NaN or -0 lost")
+	}
+	// 超出 float64 范围的 80 位值
+	if huge := (Float80{7: 0x80, 8: 0xff, 9: 0x7f}); !math.IsInf(huge.Float64(), 1) {
+		t.Errorf("huge Float80 = %v", huge.Float64())
+	}
+}
+
+func TestSIMDRegisters(t *testing.T) {
+	images := map[string]string{"xmm3": "000000000000f03f0000000000000040", "st0": "0000000000000080ff3f", "mxcsr": "801f0000", "x87cw": "7f02"}
+	p := &fakeServer{}
+	for _, path := range []string{"/Register/Vector", "/Register/FPU"} {
+		p.handle(path, func(w http.ResponseWriter, r *http.Request) {
+			name := r.URL.Query().Get("register")
+			if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+				images[name] = string(body)
+				w.Write([]byte("Register set successfully"))
+				return
+			}
+			image, ok := images[name]
+			if !ok {
+				http.Error(w, "Unknown register", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(image))
+		})
+	}
+	reg := p.client(t).X64dbg().Register
+	requests := func() []string {
+		return p.sent(func(r fakeRequest) string { return r.Method + " " + r.Path + " " + r.Query.Get("register") })
+	}
+
+	if got := reg.GetXMM(3).Float64x2(); got != [2]float64{1, 2} {
+		t.Errorf("XMM3 = %v", got)
+	}
+	reg.SetXMM(3, VectorFromFloat32x4([4]float32{1, 2, 3, 4}))
+	if got := reg.GetXMM(3).Float32x4(); got != [4]float32{1, 2, 3, 4} {
+		t.Errorf("XMM3 after set = %v", got)
+	}
+	var z Vector512
+	z[63] = 0xff
+	reg.SetZMM(31, z)
+	if got := reg.GetZMM(31); got != z {
+		t.Errorf("ZMM31 = %x", got)
+	}
+	reg.SetYMM(0, Vector256{1})
+	reg.SetK(7, 0xf0f0)
+	if got := reg.GetK(7); got != 0xf0f0 || images["k7"] != "f0f0000000000000" {
+		t.Errorf("K7 = %#x, image %s", got, images["k7"])
+	}
+	if got := reg.GetST(0).Float64(); got != 1 {
+		t.Errorf("ST0 = %v", got)
+	}
+	reg.SetST(7, NewFloat80(-2.5))
+	if got := reg.GetST(7).Float64(); got != -2.5 {
+		t.Errorf("ST7 = %v", got)
+	}
+	if reg.GetMXCSR() != 0x1f80 || reg.GetX87ControlWord() != 0x27f {
+		t.Errorf("MXCSR %#x, control word %#x", reg.GetMXCSR(), reg.GetX87ControlWord())
+	}
+	reg.SetMXCSR(0x9fc0)
+	reg.SetX87StatusWord(0x3800)
+	if images["mxcsr"] != "c09f0000" || reg.GetX87StatusWord() != 0x3800 {
+		t.Errorf("MXCSR image %s, status word %#x", images["mxcsr"], reg.GetX87StatusWord())
+	}
+	if _, err := reg.TryGetXMM(5); err == nil {
+		t.Error("unknown register read")
+	}
+
+	p.reset()
+	for _, err := range []error{
+		func() error { _, err := reg.TryGetXMM(32); return err }(),
+		func() error { _, err := reg.TryGetK(8); return err }(),
+		func() error { _, err := reg.TryGetST(-1); return err }(),
+		reg.TrySetYMM(40, Vector256{}),
+	} {
+		if err == nil {
+			t.Error("register out of range accepted")
+		}
+	}
+	if len(requests()) != 0 {
+		t.Errorf("out of range registers sent: %q", requests())
+	}
+
+	images["xmm1"] = "00"
+	if _, err := reg.TryGetXMM(1); err == nil {
+		t.Error("short image accepted")
+	}
+	if !slices.Equal(requests(), []string{"GET /Register/Vector xmm1"}) {
+		t.Errorf("requests: %q", requests())
+	}
+}
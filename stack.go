@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// String is a line of x64dbg's call stack view: "0x7ffd3e0b5a22 kernel32.dll!CreateFileW+0x12".
+func (f StackFrame) String() string {
+	s := fmt.Sprintf("0x%x", uint(f.Address))
+	if f.Symbol != "" {
+		s += " " + f.Module + "!" + f.Symbol
+		if f.Displacement != 0 {
+			s += fmt.Sprintf("+0x%x", uint(f.Displacement))
+		}
+	} else if f.Module != "" {
+		s += " " + f.Module
+	}
+	if f.Comment != "" {
+		s += " ; " + f.Comment
+	}
+	return s
+}
+
+// String is a line of printstack: "0x14fe28 0x7ff6a1b2104a a.exe!main+0x4a ; return to ...".
+func (s StackSlot) String() string {
+	line := fmt.Sprintf("0x%x 0x%x", uint(s.Address), uint(s.Value))
+	if s.Symbol != "" {
+		line += " " + s.Symbol
+	}
+	if s.Comment != "" {
+		line += " ; " + s.Comment
+	}
+	return line
+}
+
+// threadParams 0 是当前线程
+func threadParams(threadID int) map[string]string {
+	if threadID == 0 {
+		return map[string]string{}
+	}
+	return map[string]string{"thread": strconv.Itoa(threadID)}
+}
+
+// CallStack walks the stack of thread threadID, 0 for the current thread, the innermost frame first.
+func (s stack) CallStack(threadID int) []StackFrame {
+	return must(s.TryCallStack(threadID))
+}
+func (s stack) TryCallStack(threadID int) ([]StackFrame, error) {
+	return s.CallStackContext(context.Background(), threadID)
+}
+func (s stack) CallStackContext(ctx context.Context, threadID int) ([]StackFrame, error) {
+	return tryRequest[[]StackFrame](s.client, ctx, "Stack/CallStack", threadParams(threadID))
+}
+
+// Dump reads count pointer sized slots from the stack pointer of thread threadID up, each with the
+// comment of x64dbg's stack view and the symbol its value points to, like printstack does.
+func (s stack) Dump(threadID int, count int) []StackSlot {
+	return must(s.TryDump(threadID, count))
+}
+func (s stack) TryDump(threadID int, count int) ([]StackSlot, error) {
+	return s.DumpContext(context.Background(), threadID, count)
+}
+func (s stack) DumpContext(ctx context.Context, threadID int, count int) ([]StackSlot, error) {
+	if count < 1 || count > 0x10000 {
+		return nil, fmt.Errorf("x64dbg: Stack/Dump: count %d not in 1..0x10000", count)
+	}
+	params := threadParams(threadID)
+	params["count"] = strconv.Itoa(count)
+	return tryRequest[[]StackSlot](s.client, ctx, "Stack/Dump", params)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStackCallStack(t *testing.T) {
+	p := &fakeServer{}
+	for _, path := range []string{"/Stack/CallStack", "/Stack/Dump"} {
+		p.handle(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("thread") == "99" {
+				http.Error(w, "Thread not found", http.StatusNotFound)
+				return
+			}
+			body, _ := os.ReadFile(filepath.Join("testdata", "plugin", strings.ReplaceAll(r.URL.Path[1:], "/", "_")+".json"))
+			w.Write(body)
+		})
+	}
+	s := p.client(t).X64dbg().Stack
+
+	frames := s.CallStack(0)
+	if len(frames) != 2 || frames[0].String() != "0x7ffd3e0b5a22 kernel32.dll!CreateFileW+0x12 ; return to a.exe.7FF6A1B2104A from kernel32.CreateFileW" ||
+		frames[1].String() != "0x7ff6a1b2104a a.exe ; return to kernel32.BaseThreadInitThunk+10 from a.exe.7FF6A1B2104A" {
+		t.Errorf("CallStack: %v", frames)
+	}
+	s.CallStack(4242)
+	if _, err := s.TryCallStack(99); err == nil {
+		t.Error("unknown thread walked")
+	}
+
+	slots := s.Dump(0, 3)
+	if len(slots) != 3 || slots[0].String() != "0x14fe20 0x0" || slots[1].String() != "0x14fe28 0x7ff6a1b2104a a.exe!main+0x4a ; return to a.exe.7FF6A1B2104A from ???" {
+		t.Errorf("Dump: %v", slots)
+	}
+	s.Dump(4242, 16)
+	if _, err := s.TryDump(0, 0); err == nil {
+		t.Error("empty dump accepted")
+	}
+
+	want := []string{
+		"/Stack/CallStack  ",
+		"/Stack/CallStack 4242 ",
+		"/Stack/CallStack 99 ",
+		"/Stack/Dump  3",
+		"/Stack/Dump 4242 16",
+	}
+	requests := p.sent(func(r fakeRequest) string { return r.Path + " " + r.Query.Get("thread") + " " + r.Query.Get("count") })
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests:\n%q\nwant\n%q", requests, want)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// SymbolType is the SYMBOLTYPE of bridgemain.h.
+type SymbolType string
+
+const (
+	SymbolImport SymbolType = "import"
+	SymbolExport SymbolType = "export"
+	SymbolDebug  SymbolType = "symbol" // from a pdb
+)
+
+// Name is the undecorated name, the decorated one when there is none.
+func (s SymbolInfo) Name() string {
+	if s.Undecorated != "" {
+		return s.Undecorated
+	}
+	return s.Decorated
+}
+
+// String is how x64dbg labels the address, "kernel32.dll!CreateFileW+0x12".
+func (s SymbolInfo) String() string {
+	name := s.Module + "!" + s.Name()
+	if s.Displacement != 0 {
+		name += fmt.Sprintf("+0x%x", uint(s.Displacement))
+	}
+	return name
+}
+
+// symbolCache keeps what FromAddress and Resolve found per module base. Load, Unload and Download
+// drop the module they work on. The debug events the client reads drop a module that unloads or a
+// base another dll loads at, and everything when the process exits, Client.Connect starts over too.
+// Without an Events subscription call symbol.ClearCache after a restart of the debuggee.
+type symbolCache struct {
+	mu      sync.Mutex
+	modules map[HexInt]*moduleSymbols
+}
+
+type moduleSymbols struct {
+	name      string
+	byAddress map[HexInt]SymbolInfo
+	byName    map[string]SymbolInfo
+}
+
+func (c *symbolCache) fromAddress(address HexInt) (SymbolInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, m := range c.modules {
+		if info, ok := m.byAddress[address]; ok {
+			return info, true
+		}
+	}
+	return SymbolInfo{}, false
+}
+
+func (c *symbolCache) resolve(name string) (SymbolInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, m := range c.modules {
+		if info, ok := m.byName[name]; ok {
+			return info, true
+		}
+	}
+	return SymbolInfo{}, false
+}
+
+// add stores info under its module base, as the answer for address and, if not empty, name.
+func (c *symbolCache) add(address HexInt, name string, info SymbolInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.modules == nil {
+		c.modules = map[HexInt]*moduleSymbols{}
+	}
+	m := c.modules[info.Base]
+	if m == nil {
+		m = &moduleSymbols{name: info.Module, byAddress: map[HexInt]SymbolInfo{}, byName: map[string]SymbolInfo{}}
+		c.modules[info.Base] = m
+	}
+	m.byAddress[address] = info
+	if name != "" {
+		m.byName[name] = info
+	}
+}
+
+// drop forgets the module named name, "kernel32" or "kernel32.dll", and everything for an empty name.
+func (c *symbolCache) drop(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if name == "" {
+		c.modules = nil
+		return
+	}
+	for base, m := range c.modules {
+		if strings.EqualFold(m.name, name) || strings.EqualFold(strings.TrimSuffix(m.name, filepath.Ext(m.name)), name) {
+			delete(c.modules, base)
+		}
+	}
+}
+
+// follow keeps the cache in step with the debuggee, readEvents passes it every event.
+func (c *symbolCache) follow(e DebugEvent) {
+	switch e.Kind {
+	case EventLoadDll, EventUnloadDll:
+		c.mu.Lock()
+		delete(c.modules, e.Address)
+		c.mu.Unlock()
+	case EventExitProcess:
+		c.drop("")
+	}
+}
+
+func (s symbol) cache() *symbolCache { return &s.client.shared().symbols }
+
+// FromAddress finds the symbol at or before address in its module, Displacement is the distance.
+func (s symbol) FromAddress(address int) SymbolInfo {
+	return must(s.TryFromAddress(address))
+}
+func (s symbol) TryFromAddress(address int) (SymbolInfo, error) {
+	return s.FromAddressContext(context.Background(), address)
+}
+func (s symbol) FromAddressContext(ctx context.Context, address int) (SymbolInfo, error) {
+	if info, ok := s.cache().fromAddress(HexInt(address)); ok {
+		return info, nil
+	}
+	info, err := tryRequest[SymbolInfo](s.client, ctx, "Symbol/FromAddress", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+	if err != nil {
+		return SymbolInfo{}, err
+	}
+	s.cache().add(HexInt(address), "", info)
+	return info, nil
+}
+
+// Resolve finds a symbol by name: "kernel32!CreateFileW", "kernel32.dll!CreateFileW" or just
+// "CreateFileW", the WinDbg ! is x64dbg's : ("kernel32:CreateFileW" works as well).
+func (s symbol) Resolve(name string) SymbolInfo {
+	return must(s.TryResolve(name))
+}
+func (s symbol) TryResolve(name string) (SymbolInfo, error) {
+	return s.ResolveContext(context.Background(), name)
+}
+func (s symbol) ResolveContext(ctx context.Context, name string) (SymbolInfo, error) {
+	key := strings.ToLower(strings.Replace(name, "!", ":", 1))
+	if info, ok := s.cache().resolve(key); ok {
+		return info, nil
+	}
+	info, err := tryRequest[SymbolInfo](s.client, ctx, "Symbol/Resolve", map[string]string{"name": strings.Replace(name, "!", ":", 1)})
+	if err != nil {
+		return SymbolInfo{}, err
+	}
+	s.cache().add(info.Address+info.Displacement, key, info)
+	return info, nil
+}
+
+// List enumerates the symbols of a module: exports, imports and, once loaded, those of its pdb.
+func (s symbol) List(moduleName string) []SymbolInfo {
+	return must(s.TryList(moduleName))
+}
+func (s symbol) TryList(moduleName string) ([]SymbolInfo, error) {
+	return s.ListContext(context.Background(), moduleName)
+}
+func (s symbol) ListContext(ctx context.Context, moduleName string) ([]SymbolInfo, error) {
+	return tryRequest[[]SymbolInfo](s.client, ctx, "Symbol/List", map[string]string{"module": moduleName})
+}
+
+// ClearCache forgets every symbol FromAddress and Resolve cached.
+func (s symbol) ClearCache() { s.cache().drop("") }
+
+// exec runs a command that changes the symbols of moduleName, its cached symbols go stale.
+func (s symbol) exec(ctx context.Context, moduleName string, format string, args ...any) error {
+	_, err := command{s.client}.ExecContext(ctx, fmt.Sprintf(format, args...))
+	s.cache().drop(moduleName)
+	return err
+}
+
+// Load loads pdbPath as the symbols of moduleName, force skips the pdb signature check.
+func (s symbol) Load(moduleName string, pdbPath string, force bool) {
+	mylog.Check(s.TryLoad(moduleName, pdbPath, force))
+}
+func (s symbol) TryLoad(moduleName string, pdbPath string, force bool) error {
+	return s.LoadContext(context.Background(), moduleName, pdbPath, force)
+}
+func (s symbol) LoadContext(ctx context.Context, moduleName string, pdbPath string, force bool) error {
+	if force {
+		return s.exec(ctx, moduleName, "symload %s, %s, 1", strconv.Quote(moduleName), strconv.Quote(pdbPath))
+	}
+	return s.exec(ctx, moduleName, "symload %s, %s", strconv.Quote(moduleName), strconv.Quote(pdbPath))
+}
+func (s symbol) Unload(moduleName string) { mylog.Check(s.TryUnload(moduleName)) }
+func (s symbol) TryUnload(moduleName string) error {
+	return s.UnloadContext(context.Background(), moduleName)
+}
+func (s symbol) UnloadContext(ctx context.Context, moduleName string) error {
+	return s.exec(ctx, moduleName, "symunload %s", strconv.Quote(moduleName))
+}
+
+// Download fetches the pdb of moduleName from the symbol server into the store, see SetStorePath,
+// an empty moduleName downloads the symbols of every loaded module.
+func (s symbol) Download(moduleName string) { mylog.Check(s.TryDownload(moduleName)) }
+func (s symbol) TryDownload(moduleName string) error {
+	return s.DownloadContext(context.Background(), moduleName)
+}
+func (s symbol) DownloadContext(ctx context.Context, moduleName string) error {
+	if moduleName == "" {
+		return s.exec(ctx, moduleName, "symdownload")
+	}
+	return s.exec(ctx, moduleName, "symdownload %s", strconv.Quote(moduleName))
+}
+
+// StorePath is the local symbol store symdownload saves pdbs to, the Symbols/CachePath setting of x64dbg.
+// It works without a debuggee, so the store can be set up before debugging starts.
+func (s symbol) StorePath() string {
+	return must(s.TryStorePath())
+}
+func (s symbol) TryStorePath() (string, error) {
+	return s.StorePathContext(context.Background())
+}
+func (s symbol) StorePathContext(ctx context.Context) (string, error) {
+	return tryRequest[string](s.client, ctx, "Symbol/StorePath", nil)
+}
+func (s symbol) SetStorePath(path string) { mylog.Check(s.TrySetStorePath(path)) }
+func (s symbol) TrySetStorePath(path string) error {
+	return s.SetStorePathContext(context.Background(), path)
+}
+func (s symbol) SetStorePathContext(ctx context.Context, path string) error {
+	_, err := tryPost[string](s.client, ctx, "Symbol/StorePath", nil, path)
+	return err
+}
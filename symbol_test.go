@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSymbol(t *testing.T) {
+	store := `.\symbols`
+	p := &fakeServer{}
+	for _, path := range []string{"/Symbol/FromAddress", "/Symbol/Resolve"} {
+		p.handle(path, func(w http.ResponseWriter, r *http.Request) {
+			if q := r.URL.Query(); q.Get("addr") == "0x10000" || q.Get("name") == "nosuch" {
+				http.Error(w, "Symbol not found", http.StatusNotFound)
+				return
+			}
+			body, _ := os.ReadFile(filepath.Join("testdata", "plugin", "Symbol_FromAddress.json"))
+			w.Write(body)
+		})
+	}
+	p.handle("/Symbol/List", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := os.ReadFile(filepath.Join("testdata", "plugin", "Symbol_List.json"))
+		w.Write(body)
+	})
+	p.handle("/Symbol/StorePath", func(w http.ResponseWriter, r *http.Request) {
+		if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+			store = string(body)
+		}
+		w.Write([]byte(store))
+	})
+	p.handle("/ExecCommand", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Command executed successfully (no output captured)"))
+	})
+	s := p.client(t).X64dbg().Symbol
+	requests := func() []string {
+		return p.sent(func(r fakeRequest) string {
+			return r.Path + " " + r.Query.Get("addr") + r.Query.Get("name") + r.Query.Get("module")
+		})
+	}
+
+	info := s.FromAddress(0x7ffd3e0b5a22)
+	if info.String() != "kernel32.dll!CreateFileW+0x12" || info.Base != 0x7ffd3e0a0000 {
+		t.Errorf("FromAddress: %v %+v", info, info)
+	}
+	if got := s.FromAddress(0x7ffd3e0b5a22); got != info {
+		t.Errorf("cached FromAddress: %+v", got)
+	}
+	if got := s.Resolve("kernel32!CreateFileW"); got != info {
+		t.Errorf("Resolve: %+v", got)
+	}
+	s.Resolve("KERNEL32:createfilew")
+	if _, err := s.TryFromAddress(0x10000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("no symbol: %v, want ErrNotFound", err)
+	}
+	want := []string{
+		"/Symbol/FromAddress 0x7ffd3e0b5a22",
+		"/Symbol/Resolve kernel32:CreateFileW",
+		"/Symbol/FromAddress 0x10000",
+	}
+	if !slices.Equal(requests(), want) {
+		t.Errorf("requests:\n%q\nwant\n%q", requests(), want)
+	}
+
+	// symbols of another module stay cached
+	p.reset()
+	s.Unload("user32")
+	s.FromAddress(0x7ffd3e0b5a22)
+	s.Unload("kernel32")
+	s.FromAddress(0x7ffd3e0b5a22)
+	s.Load("kernel32.dll", `C:\pdb\kernel32.pdb`, true)
+	s.Resolve("kernel32!CreateFileW")
+	s.Download("")
+	s.ClearCache()
+	s.FromAddress(0x7ffd3e0b5a22)
+	want = []string{
+		"/ExecCommand ",
+		"/ExecCommand ",
+		"/Symbol/FromAddress 0x7ffd3e0b5a22",
+		"/ExecCommand ",
+		"/Symbol/Resolve kernel32:CreateFileW",
+		"/ExecCommand ",
+		"/Symbol/FromAddress 0x7ffd3e0b5a22",
+	}
+	if !slices.Equal(requests(), want) {
+		t.Errorf("requests after unload:\n%q\nwant\n%q", requests(), want)
+	}
+	wantCommands := []string{
+		`symunload "user32"`,
+		`symunload "kernel32"`,
+		`symload "kernel32.dll", "C:\\pdb\\kernel32.pdb", 1`,
+		`symdownload`,
+	}
+	if commands := p.bodies("/ExecCommand"); !slices.Equal(commands, wantCommands) {
+		t.Errorf("commands:\n%q\nwant\n%q", commands, wantCommands)
+	}
+
+	// 模块卸载、同一基址上换了 dll 或进程退出后缓存作废
+	p.reset()
+	s.cache().follow(DebugEvent{Kind: EventUnloadDll, Address: 0x10000000})
+	s.FromAddress(0x7ffd3e0b5a22)
+	s.cache().follow(DebugEvent{Kind: EventUnloadDll, Address: 0x7ffd3e0a0000})
+	s.FromAddress(0x7ffd3e0b5a22)
+	s.cache().follow(DebugEvent{Kind: EventLoadDll, Address: 0x7ffd3e0a0000, Module: "other.dll"})
+	s.FromAddress(0x7ffd3e0b5a22)
+	s.cache().follow(DebugEvent{Kind: EventExitProcess})
+	s.Resolve("kernel32!CreateFileW")
+	want = []string{
+		"/Symbol/FromAddress 0x7ffd3e0b5a22",
+		"/Symbol/FromAddress 0x7ffd3e0b5a22",
+		"/Symbol/Resolve kernel32:CreateFileW",
+	}
+	if !slices.Equal(requests(), want) {
+		t.Errorf("requests after debug events:\n%q\nwant\n%q", requests(), want)
+	}
+
+	list := s.List("kernel32.dll")
+	if len(list) != 3 || list[2].Name() != "int __cdecl BaseDllInit(void *)" || list[1].Type != SymbolImport {
+		t.Errorf("List: %+v", list)
+	}
+
+	if got := s.StorePath(); got != `.\symbols` {
+		t.Errorf("StorePath = %q", got)
+	}
+	s.SetStorePath(`D:\symstore`)
+	if got := s.StorePath(); got != `D:\symstore` {
+		t.Errorf("StorePath after set = %q", got)
+	}
+}
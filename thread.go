@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ddkwork/golibrary/std/mylog"
+)
+
+// ThreadPriority mirrors THREADPRIORITY of bridgemain.h.
+type ThreadPriority int
+
+const (
+	ThreadPriorityIdle         ThreadPriority = -15
+	ThreadPriorityLowest       ThreadPriority = -2
+	ThreadPriorityBelowNormal  ThreadPriority = -1
+	ThreadPriorityNormal       ThreadPriority = 0
+	ThreadPriorityAboveNormal  ThreadPriority = 1
+	ThreadPriorityHighest      ThreadPriority = 2
+	ThreadPriorityTimeCritical ThreadPriority = 15
+	ThreadPriorityUnknown      ThreadPriority = 0x7FFFFFFF
+)
+
+// the names setthreadpriority takes
+var threadPriorityNames = map[ThreadPriority]string{
+	ThreadPriorityIdle:         "Idle",
+	ThreadPriorityLowest:       "Lowest",
+	ThreadPriorityBelowNormal:  "BelowNormal",
+	ThreadPriorityNormal:       "Normal",
+	ThreadPriorityAboveNormal:  "AboveNormal",
+	ThreadPriorityHighest:      "Highest",
+	ThreadPriorityTimeCritical: "TimeCritical",
+	ThreadPriorityUnknown:      "Unknown",
+}
+
+func (p ThreadPriority) String() string {
+	if name, ok := threadPriorityNames[p]; ok {
+		return name
+	}
+	return "ThreadPriority(" + strconv.Itoa(int(p)) + ")"
+}
+
+// x64dbg 表达式默认十六进制，线程 id 一律按 0x 传
+func (t thread) exec(ctx context.Context, format string, args ...any) error {
+	_, err := command{t.client}.ExecContext(ctx, fmt.Sprintf(format, args...))
+	return err
+}
+
+func (t thread) List() []ThreadInfo {
+	return must(t.TryList())
+}
+func (t thread) TryList() ([]ThreadInfo, error) {
+	return t.ListContext(context.Background())
+}
+func (t thread) ListContext(ctx context.Context) ([]ThreadInfo, error) {
+	return tryRequest[[]ThreadInfo](t.client, ctx, "Thread/List", nil)
+}
+
+// find returns the first thread of List match accepts.
+func (t thread) find(ctx context.Context, what string, match func(ThreadInfo) bool) (ThreadInfo, error) {
+	list, err := t.ListContext(ctx)
+	if err != nil {
+		return ThreadInfo{}, err
+	}
+	for _, info := range list {
+		if match(info) {
+			return info, nil
+		}
+	}
+	return ThreadInfo{}, fmt.Errorf("x64dbg: thread %s: %w", what, ErrNotFound)
+}
+
+// Current is the thread x64dbg shows registers and the stack of, see Switch.
+func (t thread) Current() ThreadInfo {
+	return must(t.TryCurrent())
+}
+func (t thread) TryCurrent() (ThreadInfo, error) {
+	return t.CurrentContext(context.Background())
+}
+func (t thread) CurrentContext(ctx context.Context) (ThreadInfo, error) {
+	return t.find(ctx, "current", func(info ThreadInfo) bool { return info.Current })
+}
+func (t thread) Get(id int) ThreadInfo {
+	return must(t.TryGet(id))
+}
+func (t thread) TryGet(id int) (ThreadInfo, error) {
+	return t.GetContext(context.Background(), id)
+}
+func (t thread) GetContext(ctx context.Context, id int) (ThreadInfo, error) {
+	return t.find(ctx, strconv.Itoa(id), func(info ThreadInfo) bool { return info.ID == id })
+}
+
+// Create starts a thread at entry with argument arg in the debuggee and returns its id. x64dbg lists
+// the thread only after it processed the creation, which takes the debuggee running, Get it after the
+// next stop.
+func (t thread) Create(entry int, arg int) int {
+	return must(t.TryCreate(entry, arg))
+}
+func (t thread) TryCreate(entry int, arg int) (int, error) {
+	return t.CreateContext(context.Background(), entry, arg)
+}
+func (t thread) CreateContext(ctx context.Context, entry int, arg int) (int, error) {
+	return tryRequest[int](t.client, ctx, "Thread/Create", map[string]string{"entry": fmt.Sprintf("0x%x", entry), "arg": fmt.Sprintf("0x%x", arg)})
+}
+
+// Switch makes id the current thread of x64dbg.
+func (t thread) Switch(id int) { mylog.Check(t.TrySwitch(id)) }
+func (t thread) TrySwitch(id int) error {
+	return t.SwitchContext(context.Background(), id)
+}
+func (t thread) SwitchContext(ctx context.Context, id int) error {
+	return t.exec(ctx, "switchthread 0x%x", id)
+}
+func (t thread) Suspend(id int) { mylog.Check(t.TrySuspend(id)) }
+func (t thread) TrySuspend(id int) error {
+	return t.SuspendContext(context.Background(), id)
+}
+func (t thread) SuspendContext(ctx context.Context, id int) error {
+	return t.exec(ctx, "suspendthread 0x%x", id)
+}
+func (t thread) Resume(id int) { mylog.Check(t.TryResume(id)) }
+func (t thread) TryResume(id int) error {
+	return t.ResumeContext(context.Background(), id)
+}
+func (t thread) ResumeContext(ctx context.Context, id int) error {
+	return t.exec(ctx, "resumethread 0x%x", id)
+}
+func (t thread) Kill(id int, exitCode uint32) { mylog.Check(t.TryKill(id, exitCode)) }
+func (t thread) TryKill(id int, exitCode uint32) error {
+	return t.KillContext(context.Background(), id, exitCode)
+}
+func (t thread) KillContext(ctx context.Context, id int, exitCode uint32) error {
+	return t.exec(ctx, "killthread 0x%x, 0x%x", id, exitCode)
+}
+func (t thread) SuspendAll() { mylog.Check(t.TrySuspendAll()) }
+func (t thread) TrySuspendAll() error {
+	return t.SuspendAllContext(context.Background())
+}
+func (t thread) SuspendAllContext(ctx context.Context) error {
+	return t.exec(ctx, "suspendallthreads")
+}
+func (t thread) ResumeAll() { mylog.Check(t.TryResumeAll()) }
+func (t thread) TryResumeAll() error {
+	return t.ResumeAllContext(context.Background())
+}
+func (t thread) ResumeAllContext(ctx context.Context) error {
+	return t.exec(ctx, "resumeallthreads")
+}
+func (t thread) SetPriority(id int, priority ThreadPriority) {
+	mylog.Check(t.TrySetPriority(id, priority))
+}
+func (t thread) TrySetPriority(id int, priority ThreadPriority) error {
+	return t.SetPriorityContext(context.Background(), id, priority)
+}
+func (t thread) SetPriorityContext(ctx context.Context, id int, priority ThreadPriority) error {
+	if _, ok := threadPriorityNames[priority]; !ok || priority == ThreadPriorityUnknown {
+		return fmt.Errorf("x64dbg: setthreadpriority: invalid priority %v", priority)
+	}
+	return t.exec(ctx, "setthreadpriority 0x%x, %s", id, priority)
+}
+func (t thread) SetName(id int, name string) { mylog.Check(t.TrySetName(id, name)) }
+func (t thread) TrySetName(id int, name string) error {
+	return t.SetNameContext(context.Background(), id, name)
+}
+func (t thread) SetNameContext(ctx context.Context, id int, name string) error {
+	return t.exec(ctx, "threadsetname 0x%x, %s", id, strconv.Quote(name))
+}
+
+// GetRegister reads reg in the context of thread id, the plugin switches to it for the read and back
+// again, so the current thread of x64dbg does not change.
+func (t thread) GetRegister(id int, reg RegisterEnum) uint {
+	return must(t.TryGetRegister(id, reg))
+}
+func (t thread) TryGetRegister(id int, reg RegisterEnum) (uint, error) {
+	return t.GetRegisterContext(context.Background(), id, reg)
+}
+func (t thread) GetRegisterContext(ctx context.Context, id int, reg RegisterEnum) (uint, error) {
+	if err := (RegisterManager{t.client}).checkKnown(reg); err != nil {
+		return 0, err
+	}
+	return tryRequest[uint](t.client, ctx, "Register/Get", map[string]string{"register": reg.String(), "thread": strconv.Itoa(id)})
+}
+func (t thread) SetRegister(id int, reg RegisterEnum, value uint) bool {
+	return must(t.TrySetRegister(id, reg, value))
+}
+func (t thread) TrySetRegister(id int, reg RegisterEnum, value uint) (bool, error) {
+	return t.SetRegisterContext(context.Background(), id, reg, value)
+}
+func (t thread) SetRegisterContext(ctx context.Context, id int, reg RegisterEnum, value uint) (bool, error) {
+	if err := (RegisterManager{t.client}).checkKnown(reg); err != nil {
+		return false, err
+	}
+	return tryRequest[bool](t.client, ctx, "Register/Set", map[string]string{"register": reg.String(), "value": strconv.FormatUint(uint64(value), 16), "thread": strconv.Itoa(id)})
+}
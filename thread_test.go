@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeThreadPlugin keeps a thread table the thread commands edit, Register/Get?thread= answers from
+// the per thread register values like ThreadSwitch in MCPx64dbg.cpp.
+type fakeThreadPlugin struct {
+	fakeServer
+	threads []ThreadInfo
+	rax     map[int]uint
+	created int
+}
+
+func (p *fakeThreadPlugin) exec(cmd string) bool {
+	name, args, _ := strings.Cut(cmd, " ")
+	argv := strings.Split(args, ", ")
+	id, _ := strconv.ParseInt(strings.TrimPrefix(argv[0], "0x"), 16, 64)
+	find := func() *ThreadInfo {
+		for i := range p.threads {
+			if p.threads[i].ID == int(id) {
+				return &p.threads[i]
+			}
+		}
+		return nil
+	}
+	switch name {
+	case "createthread":
+		// 和 x64dbg 一样，新线程要等调试对象跑起来才进线程列表
+		p.created = 9000 + len(p.threads)
+		return true
+	case "suspendallthreads", "resumeallthreads":
+		return true
+	}
+	t := find()
+	if t == nil {
+		return false
+	}
+	switch name {
+	case "switchthread":
+		for i := range p.threads {
+			p.threads[i].Current = &p.threads[i] == t
+		}
+	case "suspendthread":
+		t.SuspendCount++
+	case "resumethread":
+		t.SuspendCount--
+	case "setthreadpriority":
+		for priority, n := range threadPriorityNames {
+			if n == argv[1] {
+				t.Priority = priority
+			}
+		}
+	case "threadsetname":
+		t.Name, _ = strconv.Unquote(argv[1])
+	case "killthread":
+		p.threads = append(p.threads[:t.Number], p.threads[t.Number+1:]...)
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *fakeThreadPlugin) client(t *testing.T) x64dbg {
+	t.Helper()
+	p.handle("/ExecCommand", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !p.exec(string(body)) {
+			http.Error(w, "Command execution failed", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("Command executed successfully (no output captured)"))
+	})
+	p.handle("/Thread/List", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(p.threads)
+	})
+	p.handle("/Thread/Create", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if !p.exec("createthread " + q.Get("entry") + ", " + q.Get("arg")) {
+			http.Error(w, "Failed to create thread", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, p.created)
+	})
+	p.handle("/Register/Get", func(w http.ResponseWriter, r *http.Request) {
+		id := -1
+		for _, t := range p.threads {
+			if t.Current {
+				id = t.ID
+			}
+		}
+		if r.URL.Query().Has("thread") {
+			id, _ = strconv.Atoi(r.URL.Query().Get("thread"))
+		}
+		v, ok := p.rax[id]
+		if !ok {
+			http.Error(w, "Thread not found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, "0x%x", v)
+	})
+	return p.fakeServer.client(t).X64dbg()
+}
+
+func TestThread(t *testing.T) {
+	p := &fakeThreadPlugin{
+		threads: []ThreadInfo{
+			{Number: 0, ID: 100, StartAddress: 0x401000, Name: "Main Thread", Current: true},
+			{Number: 1, ID: 200, StartAddress: 0x402000},
+		},
+		rax: map[int]uint{100: 1, 200: 2},
+	}
+	x := p.client(t)
+	th := x.Thread
+
+	if got := th.List(); len(got) != 2 {
+		t.Fatalf("List = %+v", got)
+	}
+	if got := th.Current(); got.ID != 100 {
+		t.Errorf("Current = %+v", got)
+	}
+
+	th.Suspend(200)
+	th.SetPriority(200, ThreadPriorityHighest)
+	th.SetName(200, `worker "1"`)
+	if got := th.Get(200); got.SuspendCount != 1 || got.Priority != ThreadPriorityHighest || got.Name != `worker "1"` {
+		t.Errorf("Get(200) = %+v", got)
+	}
+	th.Resume(200)
+
+	if got := th.GetRegister(200, RAX); got != 2 {
+		t.Errorf("GetRegister(200, RAX) = %d", got)
+	}
+	if got := x.Register.GetRAX(); got != 1 {
+		t.Errorf("RAX of the current thread = %d, GetRegister must not switch", got)
+	}
+	th.Switch(200)
+	if got := x.Register.GetRAX(); got != 2 || th.Current().ID != 200 {
+		t.Errorf("after Switch: RAX %d, current %+v", got, th.Current())
+	}
+
+	if created := th.Create(0x403000, 0x10); created != 9002 {
+		t.Errorf("Create = %d", created)
+	}
+	th.Kill(200, 0)
+	th.SuspendAll()
+	th.ResumeAll()
+
+	want := []string{
+		"suspendthread 0xc8",
+		"setthreadpriority 0xc8, Highest",
+		`threadsetname 0xc8, "worker \"1\""`,
+		"resumethread 0xc8",
+		"switchthread 0xc8",
+		"/Thread/Create 0x403000 0x10",
+		"killthread 0xc8, 0x0",
+		"suspendallthreads",
+		"resumeallthreads",
+	}
+	commands := p.sent(func(r fakeRequest) string {
+		if r.Path == "/Thread/Create" {
+			return r.Path + " " + r.Query.Get("entry") + " " + r.Query.Get("arg")
+		}
+		return r.Body
+	})
+	if !reflect.DeepEqual(commands, want) {
+		t.Errorf("commands:\n%s", strings.Join(commands, "\n"))
+	}
+
+	if _, err := th.TryGet(300); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(300): %v, want ErrNotFound", err)
+	}
+	if _, err := th.TryGetRegister(300, RAX); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRegister(300): %v, want ErrNotFound", err)
+	}
+	if err := th.TrySetPriority(200, 7); err == nil {
+		t.Error("SetPriority(7) accepted")
+	}
+}
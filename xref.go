@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// XrefType is the XREFTYPE of bridgemain.h, empty when a reference search does not tell.
+type XrefType string
+
+const (
+	XrefData XrefType = "data"
+	XrefJump XrefType = "jmp"
+	XrefCall XrefType = "call"
+)
+
+// references runs a reference search command and returns the rows it put into the reference view.
+func (x xref) references(ctx context.Context, typ XrefType, format string, args ...any) ([]Xref, error) {
+	list, err := tryPost[[]Xref](x.client, ctx, "References", nil, fmt.Sprintf(format, args...))
+	for i := range list {
+		if list[i].Type == "" {
+			list[i].Type = typ
+		}
+	}
+	return list, err
+}
+
+func (x xref) moduleRange(ctx context.Context, ref ModuleRef) (int, int, error) {
+	info, err := module{x.client}.InfoContext(ctx, ref)
+	return int(info.BaseAddress), int(info.Size), err
+}
+
+// ToAddress lists the instructions referring to address. It asks the xref database of x64dbg's
+// analysis first and, when that knows nothing, searches the module of address with reffind.
+func (x xref) ToAddress(address int) []Xref {
+	return must(x.TryToAddress(address))
+}
+func (x xref) TryToAddress(address int) ([]Xref, error) {
+	return x.ToAddressContext(context.Background(), address)
+}
+func (x xref) ToAddressContext(ctx context.Context, address int) ([]Xref, error) {
+	list, err := tryRequest[[]Xref](x.client, ctx, "Xref/Get", map[string]string{"addr": fmt.Sprintf("0x%x", address)})
+	if err != nil || len(list) > 0 {
+		return list, err
+	}
+	base, size, err := x.moduleRange(ctx, ModuleAt(address))
+	if errors.Is(err, ErrNotFound) {
+		// 不在模块里，只能靠分析数据库
+		return list, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return x.references(ctx, "", "reffind 0x%x, 0x%x, 0x%x", address, base, size)
+}
+
+// Strings lists the string references in a module, Text is the string as x64dbg shows it.
+func (x xref) Strings(ref ModuleRef) []Xref {
+	return must(x.TryStrings(ref))
+}
+func (x xref) TryStrings(ref ModuleRef) ([]Xref, error) {
+	return x.StringsContext(context.Background(), ref)
+}
+func (x xref) StringsContext(ctx context.Context, ref ModuleRef) ([]Xref, error) {
+	base, size, err := x.moduleRange(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return x.references(ctx, XrefData, "refstr 0x%x, 0x%x", base, size)
+}
+
+// Calls lists the intermodular calls of a module, Text is the destination.
+func (x xref) Calls(ref ModuleRef) []Xref {
+	return must(x.TryCalls(ref))
+}
+func (x xref) TryCalls(ref ModuleRef) ([]Xref, error) {
+	return x.CallsContext(context.Background(), ref)
+}
+func (x xref) CallsContext(ctx context.Context, ref ModuleRef) ([]Xref, error) {
+	base, size, err := x.moduleRange(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return x.references(ctx, XrefCall, "modcallfind 0x%x, 0x%x", base, size)
+}
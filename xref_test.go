@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestXref(t *testing.T) {
+	database := "[]"
+	p := &fakeServer{}
+	p.handle("/Xref/Get", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("addr") == "0x7ff6a1b21100" {
+			body, _ := os.ReadFile(filepath.Join("testdata", "plugin", "Xref_Get.json"))
+			w.Write(body)
+			return
+		}
+		w.Write([]byte(database))
+	})
+	p.handle("/References", func(w http.ResponseWriter, r *http.Request) {
+		refs, _ := os.ReadFile(filepath.Join("testdata", "plugin", "References.json"))
+		w.Write(refs)
+	})
+	p.handle("/Module/Info", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("addr") == "0x10000" {
+			http.Error(w, "Module not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name":"a.exe","base":"0x7ff6a1b20000","size":"0x9000","entry":"0x7ff6a1b21000","sectionCount":6,"path":"a.exe"}`))
+	})
+	x := p.client(t).X64dbg().Xref
+
+	if got := x.ToAddress(0x7ff6a1b21100); len(got) != 2 || got[0].Type != XrefCall || len(p.bodies("/References")) != 0 {
+		t.Errorf("ToAddress from the database: %+v, commands %q", got, p.bodies("/References"))
+	}
+	if got := x.ToAddress(0x7ff6a1b23000); len(got) != 2 || got[0].Type != "" {
+		t.Errorf("ToAddress by reffind: %+v", got)
+	}
+	if got := x.ToAddress(0x10000); len(got) != 0 {
+		t.Errorf("ToAddress outside any module: %+v", got)
+	}
+	if got := x.Strings(ModuleNamed("a.exe")); len(got) != 2 || got[0].Text != "\"hello\\n\"" || got[0].Type != XrefData {
+		t.Errorf("Strings: %+v", got)
+	}
+	if got := x.Calls(ModuleNamed("a.exe")); len(got) != 2 || got[1].Text != "kernel32.GetStdHandle" || got[1].Type != XrefCall {
+		t.Errorf("Calls: %+v", got)
+	}
+	want := []string{
+		"reffind 0x7ff6a1b23000, 0x7ff6a1b20000, 0x9000",
+		"refstr 0x7ff6a1b20000, 0x9000",
+		"modcallfind 0x7ff6a1b20000, 0x9000",
+	}
+	if commands := p.bodies("/References"); !slices.Equal(commands, want) {
+		t.Errorf("commands:\n%q\nwant\n%q", commands, want)
+	}
+
+	database = "not json"
+	if _, err := x.TryToAddress(0x7ff6a1b23000); !errors.As(err, new(*DecodeError)) {
+		t.Errorf("bad body: %v, want DecodeError", err)
+	}
+}